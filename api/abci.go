@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// abciQueryError contains error returned by the node module querier.
+type abciQueryError struct {
+	Code      uint32 `json:"code"`
+	Codespace string `json:"codespace"`
+	Log       string `json:"log"`
+}
+
+// Error returns error info as string.
+func (e abciQueryError) Error() string {
+	return fmt.Sprintf("code: %d, codespace: \"%s\", log: \"%s\"", e.Code, e.Codespace, e.Log)
+}
+
+// rpcABCIQuery requests custom module querier of the node (e.g. "custom/multisig/getWallet/dx1...")
+// using Tendermint RPC `abci_query` method and decodes JSON result to the value.
+// It is used for module queries which have no REST routes on the node.
+func (api *API) rpcABCIQuery(path string, value interface{}) error {
+	return api.rpcABCIQueryWithData(path, nil, value)
+}
+
+// rpcABCIQueryWithData requests custom module querier of the node passing JSON encoded params to it.
+func (api *API) rpcABCIQueryWithData(path string, params interface{}, value interface{}) error {
+	type responseType struct {
+		Result struct {
+			Response *struct {
+				abciQueryError
+				Value string `json:"value"`
+			} `json:"response"`
+		} `json:"result"`
+	}
+	//request
+	req := api.client.rpc.R().SetQueryParam("path", fmt.Sprintf(`"%s"`, path))
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return err
+		}
+		req.SetQueryParam("data", "0x"+hex.EncodeToString(data))
+	}
+	res, err := req.Get("/abci_query")
+	if err = processConnectionError(res, err); err != nil {
+		return err
+	}
+	//json decode
+	respValue, respErr := responseType{}, JsonRPCError{}
+	err = universalJSONDecode(res.Body(), &respValue, &respErr, func() (bool, bool) {
+		return respValue.Result.Response != nil, respErr.InternalError.Code != 0
+	})
+	if err != nil {
+		return joinErrors(err, respErr)
+	}
+	//process result
+	resp := respValue.Result.Response
+	if resp.Code != 0 {
+		return resp.abciQueryError
+	}
+	data, err := base64.StdEncoding.DecodeString(resp.Value)
+	if err != nil {
+		return err
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		data = []byte("null")
+	}
+	return json.Unmarshal(data, value)
+}
@@ -0,0 +1,188 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+////////////////////////////////////////////////////////////////
+// Amounts of coins are stored in the blockchain in "pip":
+//     1 DEL  =  10^18 pip
+// API responses contain amounts as decimal strings or numbers
+// in different formats depending on the source:
+//     node:    "1500000000000000000", "1500000000000000000.000000000000000000"
+//     gateway: "1500000000000000000", 1.5e+18
+////////////////////////////////////////////////////////////////
+
+// e18 is a multiply factor to convert value in coin (like DEL) to value in pip.
+var e18 = sdk.NewInt(1000000000000000000)
+
+// Amount contains amount of coins in pip parsed from API response.
+type Amount struct {
+	pip sdk.Int
+}
+
+// NewAmount creates amount from value in pip.
+func NewAmount(pip sdk.Int) Amount {
+	return Amount{pip: pip}
+}
+
+// ParseAmount parses amount in pip presented in any format returned by gateway or node:
+// integer, decimal or exponential notation, optionally quoted. Fractional part of pip is truncated.
+// Empty string is parsed as zero amount.
+func ParseAmount(value string) (Amount, error) {
+	value = strings.Trim(strings.TrimSpace(value), `"`)
+	if value == "" {
+		return NewAmount(sdk.ZeroInt()), nil
+	}
+	if pip, ok := sdk.NewIntFromString(value); ok {
+		return NewAmount(pip), nil
+	}
+	rat, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return Amount{}, fmt.Errorf("unable to parse amount %q", value)
+	}
+	pip := new(big.Int).Quo(rat.Num(), rat.Denom())
+	return NewAmount(sdk.NewIntFromBigInt(pip)), nil
+}
+
+// Pip returns amount in pip.
+func (a Amount) Pip() sdk.Int {
+	if a.pip.BigInt() == nil {
+		return sdk.ZeroInt()
+	}
+	return a.pip
+}
+
+// Display returns amount in whole coins (like DEL) to display it to users.
+func (a Amount) Display() sdk.Dec {
+	return PipToCoin(a.Pip())
+}
+
+// String returns amount in pip as decimal string.
+func (a Amount) String() string {
+	return a.Pip().String()
+}
+
+// PipToCoin converts value in pip to value in whole coins (like DEL).
+func PipToCoin(pip sdk.Int) sdk.Dec {
+	return sdk.NewDecFromIntWithPrec(pip, sdk.Precision)
+}
+
+// CoinToPip converts value in whole coins (like DEL) to value in pip.
+func CoinToPip(value sdk.Dec) sdk.Int {
+	return sdk.NewIntFromBigInt(value.BigInt())
+}
+
+// parseJSONAmount parses amount presented in JSON as number or as string.
+func parseJSONAmount(raw json.RawMessage) (sdk.Int, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return sdk.ZeroInt(), nil
+	}
+	amount, err := ParseAmount(string(raw))
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	return amount.Pip(), nil
+}
+
+////////////////////////////////////////////////////////////////
+// Typed accessors for result structs
+////////////////////////////////////////////////////////////////
+
+// Balances returns account balance as set of coins (symbols are lower-cased, invalid symbols cause error).
+func (r *AddressResult) Balances() (sdk.Coins, error) {
+	coins := sdk.Coins{}
+	for symbol, value := range r.Balance {
+		amount, err := ParseAmount(value)
+		if err != nil {
+			return nil, err
+		}
+		if !amount.Pip().IsPositive() {
+			continue
+		}
+		symbol = strings.ToLower(symbol)
+		if err = sdk.ValidateDenom(symbol); err != nil {
+			return nil, fmt.Errorf("invalid coin symbol %q: %w", symbol, err)
+		}
+		coins = append(coins, sdk.NewCoin(symbol, amount.Pip()))
+	}
+	sort.Sort(coins)
+	return coins, nil
+}
+
+// BalanceOf returns account balance of the coin with specified symbol.
+func (r *AddressResult) BalanceOf(symbol string) (Amount, error) {
+	return ParseAmount(r.Balance[symbol])
+}
+
+// ReserveAmount returns reserve of the coin.
+func (r *CoinResult) ReserveAmount() (Amount, error) {
+	return ParseAmount(r.Reserve)
+}
+
+// VolumeAmount returns volume of the coin.
+func (r *CoinResult) VolumeAmount() (Amount, error) {
+	return ParseAmount(r.Volume)
+}
+
+// LimitVolumeAmount returns limit volume of the coin.
+func (r *CoinResult) LimitVolumeAmount() (Amount, error) {
+	return ParseAmount(r.LimitVolume)
+}
+
+// TotalStakeAmount returns total stake in base coin.
+func (r *StakesResult) TotalStakeAmount() (Amount, error) {
+	return ParseAmount(r.TotalStake)
+}
+
+// AmountValue returns amount of staked coins.
+func (r *Stake) AmountValue() (Amount, error) {
+	return ParseAmount(r.Amount)
+}
+
+// BaseAmountValue returns amount of staked coins in base coin.
+func (r *Stake) BaseAmountValue() (Amount, error) {
+	return ParseAmount(r.BaseAmount)
+}
+
+// UnbondAmountValue returns amount of coins being unbonded.
+func (r *Stake) UnbondAmountValue() (Amount, error) {
+	return ParseAmount(r.UnbondAmount)
+}
+
+// StakeAmount returns total stake of the validator.
+func (r *ValidatorResult) StakeAmount() (Amount, error) {
+	return ParseAmount(r.Stake)
+}
+
+// MinStakeAmount returns minimum stake needed to get place in the delegators list.
+func (r *ValidatorResult) MinStakeAmount() (Amount, error) {
+	return ParseAmount(r.MinStake)
+}
+
+// QuantityValue returns amount of NFT sub tokens.
+func (r *NFT) QuantityValue() (sdk.Int, error) {
+	amount, err := ParseAmount(r.Quantity)
+	return amount.Pip(), err
+}
+
+// StartReserveAmount returns start reserve of NFT sub token.
+func (r *NFT) StartReserveAmount() (Amount, error) {
+	return ParseAmount(r.StartReserve)
+}
+
+// TotalReserveAmount returns total reserve of NFT.
+func (r *NFT) TotalReserveAmount() (Amount, error) {
+	return ParseAmount(r.TotalReserve)
+}
+
+// Amount returns balance value.
+func (e BalanceEntry) Amount() (Amount, error) {
+	return ParseAmount(e.Value)
+}
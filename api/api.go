@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -20,9 +21,9 @@ import (
 	"bitbucket.org/decimalteam/go-node/x/validator"
 )
 
-// BaseCoinSymbol is symbol of base coin in the network.
-// TODO: Request it from a gateway instead?
-const BaseCoinSymbol = "tdel"
+// BaseCoinSymbol is symbol of base coin in the test network.
+// Deprecated: use API.BaseCoin instead, it returns symbol of base coin in the connected network.
+const BaseCoinSymbol = config.SymbolTestBaseCoin
 
 // API is a struct implementing Decimal API iteraction.
 type API struct {
@@ -38,7 +39,19 @@ type API struct {
 	client *clientConn
 
 	// Parameters
-	chainID string
+	network   Network
+	chainID   string
+	configErr error
+	maxDeleg  uint64 // Node parameter "max_delegations", requested once
+	fees      *FeeSchedule
+	mtx       sync.RWMutex
+
+	// Times of blocks requested for transactions history
+	blockTimes map[uint64]time.Time
+
+	// Listing of NFT ids reused by pages of NFT list on direct connection
+	nftListing    *nftListing
+	nftListingMtx sync.Mutex
 }
 
 // Ports for REST/RPC interfaces.
@@ -46,6 +59,9 @@ type DirectConn struct {
 	// ":port"
 	PortREST string
 	PortRPC  string
+
+	// Limit of concurrent requests made by single lookup (e.g. NFT of all collections), 8 by default
+	MaxParallelRequests int
 }
 
 type clientConn struct {
@@ -67,14 +83,26 @@ func NewAPI(hostURL string, directConn *DirectConn) *API {
 }
 
 // NewAPIWithClient creates Decimal API instance with custom Resty client.
+// NOTE: Cosmos SDK config is shared by all API instances in the process, see SetupConfig.
 func NewAPIWithClient(hostURL string, restClient *resty.Client, rpcClient *resty.Client, directConn *DirectConn) *API {
+	return newAPI(Network{
+		Name:       "custom",
+		URL:        hostURL,
+		DirectConn: directConn,
+		Bech32:     DecimalBech32Prefixes,
+	}, restClient, rpcClient)
+}
+
+// newAPI creates Decimal API instance connected to the network.
+func newAPI(network Network, restClient *resty.Client, rpcClient *resty.Client) *API {
 	const (
 		defaultPortREST = ":1317"
 		defaultPortRPC  = ":26657"
 	)
 	var (
-		hostREST = hostURL
-		hostRPC  = hostURL
+		directConn = network.DirectConn
+		hostREST   = network.URL
+		hostRPC    = network.URL
 	)
 
 	if directConn != nil {
@@ -88,29 +116,61 @@ func NewAPIWithClient(hostURL string, restClient *resty.Client, rpcClient *resty
 		hostRPC += directConn.PortRPC
 	}
 
+	cfg, err := SetupConfig(network.Bech32)
+
 	return &API{
-		config: newConfig(),
-		codec:  newCodec(),
+		config: cfg,
+		codec:  sharedCodec(),
 		client: &clientConn{
 			rest: restClient.SetHostURL(hostREST),
 			rpc:  rpcClient.SetHostURL(hostRPC),
 		},
 		directConn: directConn,
+		network:    network,
+		chainID:    network.ChainID,
+		configErr:  err,
+		fees:       DefaultFeeSchedule(),
 	}
 }
 
+// Close releases idle connections of the API instance clients.
+// API instance should not be used after closing.
+func (api *API) Close() {
+	api.client.rest.GetClient().CloseIdleConnections()
+	api.client.rpc.GetClient().CloseIdleConnections()
+}
+
 // Config returns Cosmos SDK config.
 func (api *API) Config() *sdk.Config {
 	return api.config
 }
 
+// ConfigErr returns error occurred while setting up Cosmos SDK config for the API instance.
+// It is not nil only if the config was sealed before with different bech32 prefixes, then addresses
+// are formatted and parsed with wrong prefixes. NewAPIForNetwork returns this error instead of the instance.
+func (api *API) ConfigErr() error {
+	return api.configErr
+}
+
+// IsDirectConn reports whether the API instance is connected to the node directly (REST/RPC) instead of the gateway.
+func (api *API) IsDirectConn() bool {
+	return api.directConn != nil
+}
+
 // Codec returns Cosmos SDK codec.
 func (api *API) Codec() *codec.Codec {
 	return api.codec
 }
 
-// ChainID retrieves chain ID.
+// ChainID returns chain ID specified in the network profile or retrieves it from the network
+// (retrieved chain ID is remembered).
 func (api *API) ChainID() (string, error) {
+	api.mtx.RLock()
+	chainID := api.chainID
+	api.mtx.RUnlock()
+	if chainID != "" {
+		return chainID, nil
+	}
 	if api.directConn == nil {
 		return api.apiChainID()
 	} else {
@@ -125,9 +185,10 @@ func (api *API) apiChainID() (string, error) {
 		return "", err
 	}
 	//decode
-	api.chainID = string(res.Body())
+	chainID := string(res.Body())
 	//process result
-	return api.chainID, nil
+	api.setChainID(chainID)
+	return chainID, nil
 }
 
 func (api *API) restChainID() (string, error) {
@@ -152,8 +213,14 @@ func (api *API) restChainID() (string, error) {
 		return "", err
 	}
 	//
-	api.chainID = respValue.Result.NodeInfo.Network
-	return api.chainID, nil
+	api.setChainID(respValue.Result.NodeInfo.Network)
+	return respValue.Result.NodeInfo.Network, nil
+}
+
+func (api *API) setChainID(chainID string) {
+	api.mtx.Lock()
+	defer api.mtx.Unlock()
+	api.chainID = chainID
 }
 
 // Return current height (block number) of blockchain
@@ -221,62 +288,131 @@ func (api *API) restGetHeight() (uint64, error) {
 	return strconv.ParseUint(respValue.Result.SyncInfo.Height, 10, 64)
 }
 
-// newConfig initializes new Cosmos SDK configuration.
-func newConfig() *sdk.Config {
+////////////////////////////////////////////////////////////////
+// Cosmos SDK config is a process wide singleton used to encode
+// and decode bech32 addresses, so it is set up once and sealed.
+// Codec is immutable after sealing, so it is shared by all
+// API instances as well.
+////////////////////////////////////////////////////////////////
+
+const (
+	coinType           = 60
+	fullFundraiserPath = "44'/60'/0'/0/0"
+)
+
+var (
+	configMtx sync.Mutex
+
+	codecOnce sync.Once
+	codecInst *codec.Codec
+)
+
+// SetupConfig sets up and seals global Cosmos SDK config with specified bech32 prefixes.
+// It is called by API constructors and may be called any number of times (including concurrently)
+// with the same prefixes. If the config is already sealed with different parameters (e.g. by other
+// Cosmos based code in the process) it is left untouched and error is returned.
+func SetupConfig(prefixes Bech32Prefixes) (*sdk.Config, error) {
+	configMtx.Lock()
+	defer configMtx.Unlock()
+
 	cfg := sdk.GetConfig()
-	cfg.SetCoinType(60)
-	cfg.SetFullFundraiserPath("44'/60'/0'/0/0")
-	cfg.SetBech32PrefixForAccount(config.DecimalPrefixAccAddr, config.DecimalPrefixAccPub)
-	cfg.SetBech32PrefixForValidator(config.DecimalPrefixValAddr, config.DecimalPrefixValPub)
-	cfg.SetBech32PrefixForConsensusNode(config.DecimalPrefixConsAddr, config.DecimalPrefixConsPub)
-	cfg.Seal()
-	return cfg
+	if configMatches(cfg, prefixes) {
+		return cfg.Seal(), nil
+	}
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("Cosmos SDK config is already sealed with different parameters: %v", r)
+			}
+		}()
+		cfg.SetCoinType(coinType)
+		cfg.SetFullFundraiserPath(fullFundraiserPath)
+		cfg.SetBech32PrefixForAccount(prefixes.AccAddr, prefixes.AccPub)
+		cfg.SetBech32PrefixForValidator(prefixes.ValAddr, prefixes.ValPub)
+		cfg.SetBech32PrefixForConsensusNode(prefixes.ConsAddr, prefixes.ConsPub)
+		return nil
+	}()
+	if err != nil {
+		return cfg, err
+	}
+	return cfg.Seal(), nil
+}
+
+// configMatches returns true if Cosmos SDK config is already set up with specified parameters.
+func configMatches(cfg *sdk.Config, prefixes Bech32Prefixes) bool {
+	return cfg.GetCoinType() == coinType &&
+		cfg.GetFullFundraiserPath() == fullFundraiserPath &&
+		cfg.GetBech32AccountAddrPrefix() == prefixes.AccAddr &&
+		cfg.GetBech32AccountPubPrefix() == prefixes.AccPub &&
+		cfg.GetBech32ValidatorAddrPrefix() == prefixes.ValAddr &&
+		cfg.GetBech32ValidatorPubPrefix() == prefixes.ValPub &&
+		cfg.GetBech32ConsensusAddrPrefix() == prefixes.ConsAddr &&
+		cfg.GetBech32ConsensusPubPrefix() == prefixes.ConsPub
+}
+
+// sharedCodec returns Cosmos SDK codec initialized once for all API instances.
+func sharedCodec() *codec.Codec {
+	codecOnce.Do(func() {
+		codecInst = newCodec()
+	})
+	return codecInst
+}
+
+// codecMsgs contains all messages registered in the codec with their names.
+// Every message registered here must have an entry in DefaultFeeSchedule (checked by feeschedule_test.go).
+var codecMsgs = []struct {
+	msg  sdk.Msg
+	name string
+}{
+	{coin.MsgCreateCoin{}, "coin/create_coin"},
+	{coin.MsgSendCoin{}, "coin/send_coin"},
+	{coin.MsgMultiSendCoin{}, "coin/multi_send_coin"},
+	{coin.MsgBuyCoin{}, "coin/buy_coin"},
+	{coin.MsgSellCoin{}, "coin/sell_coin"},
+	{coin.MsgSellAllCoin{}, "coin/sell_all_coin"},
+	{coin.MsgUpdateCoin{}, "coin/update_coin"},
+	{coin.MsgRedeemCheck{}, "coin/redeem_check"},
+	{coin.MsgBurnCoin{}, "coin/burn_coin"},
+
+	{validator.MsgDeclareCandidate{}, "validator/declare_candidate"},
+	{validator.MsgDelegate{}, "validator/delegate"},
+	{validator.MsgSetOnline{}, "validator/set_online"},
+	{validator.MsgSetOffline{}, "validator/set_offline"},
+	{validator.MsgUnbond{}, "validator/unbond"},
+	{validator.MsgEditCandidate{}, "validator/edit_candidate"},
+	{validator.MsgDelegateNFT{}, "validator/delegate_nft"},
+	{validator.MsgUnbondNFT{}, "validator/unbond_nft"},
+
+	{nft.MsgBurnNFT{}, "nft/msg_burn"},
+	{nft.MsgMintNFT{}, "nft/msg_mint"},
+	{nft.MsgEditNFTMetadata{}, "nft/msg_edit_metadata"},
+	{nft.MsgTransferNFT{}, "nft/msg_transfer"},
+	{nft.MsgUpdateReserveNFT{}, "nft/update_reserve"},
+
+	{swap.MsgHTLT{}, "swap/msg_htlt"},
+	{swap.MsgRedeem{}, "swap/msg_redeem"},
+	{swap.MsgRefund{}, "swap/msg_refund"},
+	{swap.MsgSwapInitialize{}, "swap/msg_initialize"},
+	{swap.MsgRedeemV2{}, "swap/msg_redeem_v2"},
+	{swap.MsgChainActivate{}, "swap/msg_chain_activate"},
+	{swap.MsgChainDeactivate{}, "swap/msg_chain_deactivate"},
+
+	{multisig.MsgCreateWallet{}, "multisig/create_wallet"},
+	{multisig.MsgCreateTransaction{}, "multisig/create_transaction"},
+	{multisig.MsgSignTransaction{}, "multisig/sign_transaction"},
+
+	{gov.MsgSubmitProposal{}, "cosmos-sdk/MsgSubmitProposal"},
+	{gov.MsgVote{}, "cosmos-sdk/MsgVote"},
+	{gov.MsgSoftwareUpgradeProposal{}, "cosmos-sdk/MsgSoftwareUpgradeProposal"},
 }
 
 // newCodec initializes new Cosmos SDK codec.
 func newCodec() *codec.Codec {
 	cdc := codec.New()
 	cdc.RegisterInterface((*sdk.Msg)(nil), nil)
-	cdc.RegisterConcrete(coin.MsgCreateCoin{}, "coin/create_coin", nil)
-	cdc.RegisterConcrete(coin.MsgSendCoin{}, "coin/send_coin", nil)
-	cdc.RegisterConcrete(coin.MsgMultiSendCoin{}, "coin/multi_send_coin", nil)
-	cdc.RegisterConcrete(coin.MsgBuyCoin{}, "coin/buy_coin", nil)
-	cdc.RegisterConcrete(coin.MsgSellCoin{}, "coin/sell_coin", nil)
-	cdc.RegisterConcrete(coin.MsgSellAllCoin{}, "coin/sell_all_coin", nil)
-	cdc.RegisterConcrete(coin.MsgUpdateCoin{}, "coin/update_coin", nil)
-	cdc.RegisterConcrete(coin.MsgRedeemCheck{}, "coin/redeem_check", nil)
-	cdc.RegisterConcrete(coin.MsgBurnCoin{}, "coin/burn_coin", nil)
-
-	cdc.RegisterConcrete(validator.MsgDeclareCandidate{}, "validator/declare_candidate", nil)
-	cdc.RegisterConcrete(validator.MsgDelegate{}, "validator/delegate", nil)
-	cdc.RegisterConcrete(validator.MsgSetOnline{}, "validator/set_online", nil)
-	cdc.RegisterConcrete(validator.MsgSetOffline{}, "validator/set_offline", nil)
-	cdc.RegisterConcrete(validator.MsgUnbond{}, "validator/unbond", nil)
-	cdc.RegisterConcrete(validator.MsgEditCandidate{}, "validator/edit_candidate", nil)
-	cdc.RegisterConcrete(validator.MsgDelegateNFT{}, "validator/delegate_nft", nil)
-	cdc.RegisterConcrete(validator.MsgUnbondNFT{}, "validator/unbond_nft", nil)
-
-	cdc.RegisterConcrete(nft.MsgBurnNFT{}, "nft/msg_burn", nil)
-	cdc.RegisterConcrete(nft.MsgMintNFT{}, "nft/msg_mint", nil)
-	cdc.RegisterConcrete(nft.MsgEditNFTMetadata{}, "nft/msg_edit_metadata", nil)
-	cdc.RegisterConcrete(nft.MsgTransferNFT{}, "nft/msg_transfer", nil)
-	cdc.RegisterConcrete(nft.MsgUpdateReserveNFT{}, "nft/update_reserve", nil)
-
-	cdc.RegisterConcrete(swap.MsgHTLT{}, "swap/msg_htlt", nil)
-	cdc.RegisterConcrete(swap.MsgRedeem{}, "swap/msg_redeem", nil)
-	cdc.RegisterConcrete(swap.MsgRefund{}, "swap/msg_refund", nil)
-	cdc.RegisterConcrete(swap.MsgSwapInitialize{}, "swap/msg_initialize", nil)
-	cdc.RegisterConcrete(swap.MsgRedeemV2{}, "swap/msg_redeem_v2", nil)
-	cdc.RegisterConcrete(swap.MsgChainActivate{}, "swap/msg_chain_activate", nil)
-	cdc.RegisterConcrete(swap.MsgChainDeactivate{}, "swap/msg_chain_deactivate", nil)
-
-	cdc.RegisterConcrete(multisig.MsgCreateWallet{}, "multisig/create_wallet", nil)
-	cdc.RegisterConcrete(multisig.MsgCreateTransaction{}, "multisig/create_transaction", nil)
-	cdc.RegisterConcrete(multisig.MsgSignTransaction{}, "multisig/sign_transaction", nil)
-
-	cdc.RegisterConcrete(gov.MsgSubmitProposal{}, "cosmos-sdk/MsgSubmitProposal", nil)
-	cdc.RegisterConcrete(gov.MsgVote{}, "cosmos-sdk/MsgVote", nil)
-	cdc.RegisterConcrete(gov.MsgSoftwareUpgradeProposal{}, "cosmos-sdk/MsgSoftwareUpgradeProposal", nil)
+	for _, m := range codecMsgs {
+		cdc.RegisterConcrete(m.msg, m.name, nil)
+	}
 
 	auth.RegisterCodec(cdc)
 	codec.RegisterCrypto(cdc)
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -10,9 +12,6 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 )
 
-// const hugeGas = uint64(16 * 1024)
-const hugeGas = uint64(16 << 10)
-
 // BroadcastTxResponse contains API response.
 type BroadcastTxResponse struct {
 	OK     bool               `json:"ok"`
@@ -38,33 +37,57 @@ const (
 // you need to find the transaction by the hash and ensure that the status code equals to 0.
 
 // NewSignedTransaction creates and signs a transaction.
+// Gas wanted is computed before signing (sizes of public key and signature are fixed),
+// so the transaction is signed once. Signing is repeated only if the signed transaction
+// has unexpected size, the amount of such attempts is limited.
 func (api *API) NewSignedTransaction(msgs []sdk.Msg, feeCoins sdk.Coins, memo string, account *wallet.Account) (tx auth.StdTx, err error) {
-	// Adjust gas until it is equal to gasEstimated
-	for gas, gasEstimated := hugeGas, uint64(0); gas != gasEstimated; {
-		if gasEstimated != 0 {
-			gas = gasEstimated
-		}
-
+	gas, err := api.estimateUnsignedGasWanted(account.CreateTransaction(msgs, auth.NewStdFee(0, feeCoins), memo))
+	if err != nil {
+		return
+	}
+	for i := 0; ; i++ {
 		// Create and sign transaction
-		fee := auth.NewStdFee(gas, feeCoins)
-		tx = account.CreateTransaction(msgs, fee, memo)
-		tx, err = account.SignTransaction(tx)
+		tx, err = account.SignTransaction(account.CreateTransaction(msgs, auth.NewStdFee(gas, feeCoins), memo))
 		if err != nil {
 			return
 		}
 
-		// Estimate and adjust amount of gas wanted for the transaction
-		gasEstimated, err = api.EstimateTransactionGasWanted(tx)
+		// Ensure gas wanted is not changed after signing
+		gasEstimated, err := api.EstimateTransactionGasWanted(tx)
 		if err != nil {
-			return
+			return tx, err
+		}
+		if gasEstimated == gas {
+			return tx, nil
+		}
+		if i == maxGasIterations {
+			return tx, errors.New("unable to adjust gas wanted to the size of transaction")
 		}
+		gas = gasEstimated
 	}
-	return
 }
 
 // BroadcastSignedTransactionJSON sends transaction (presented in JSON format) to the node and returns the result.
 // If transaction is sucessful, it modified account sequence
 func (api *API) BroadcastSignedTransactionJSON(tx auth.StdTx, acc *wallet.Account) (*BroadcastTxResult, error) {
+	response, err := api.broadcastTxJSON(context.Background(), tx)
+	if err != nil {
+		return nil, err
+	}
+
+	acc.WithSequence(uint64(acc.Sequence() + 1))
+
+	return response, nil
+}
+
+// BroadcastTransaction sends signed transaction to the node and returns the result.
+// Unlike BroadcastSignedTransactionJSON it does not modify sequence of any account.
+func (api *API) BroadcastTransaction(ctx context.Context, tx auth.StdTx) (*BroadcastTxResult, error) {
+	return api.broadcastTxJSON(ctx, tx)
+}
+
+// broadcastTxJSON sends transaction (presented in JSON format) to the node and returns the result.
+func (api *API) broadcastTxJSON(ctx context.Context, tx auth.StdTx) (*BroadcastTxResult, error) {
 	var (
 		url = ""
 	)
@@ -89,7 +112,7 @@ func (api *API) BroadcastSignedTransactionJSON(tx auth.StdTx, acc *wallet.Accoun
 	}
 
 	// TODO: undefined /txs in RPC, but was found /txs in REST?
-	res, err := api.client.rest.R().SetBody(txJSON).Post(url)
+	res, err := api.client.rest.R().SetContext(ctx).SetBody(txJSON).Post(url)
 	if err = processConnectionError(res, err); err != nil {
 		return nil, err
 	}
@@ -104,11 +127,9 @@ func (api *API) BroadcastSignedTransactionJSON(tx auth.StdTx, acc *wallet.Accoun
 		if err != nil {
 			return nil, err
 		}
-		return nil, fmt.Errorf("received tx error: %s", txError.Error())
+		return nil, fmt.Errorf("received tx error: %w", txError)
 	}
 
-	acc.WithSequence(uint64(acc.Sequence() + 1))
-
 	return &response, nil
 }
 
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"fmt"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"bitbucket.org/decimalteam/decimal-go-sdk/wallet"
+)
+
+const benchMnemonicWords = "repair furnace west loud peasant false six hockey poem tube now alien service phone hazard winter favorite away sand fuel describe version tragic vendor"
+
+// BenchmarkNewSignedTransaction measures signing of bulk payouts (multisend with many recipients).
+func BenchmarkNewSignedTransaction(b *testing.B) {
+	api := NewAPI("http://localhost", nil)
+	account, err := wallet.NewAccountFromMnemonicWords(benchMnemonicWords, "")
+	if err != nil {
+		b.Fatal(err)
+	}
+	account = account.WithChainID("decimal-testnet").WithAccountNumber(1).WithSequence(1)
+	sender, err := sdk.AccAddressFromBech32(account.Address())
+	if err != nil {
+		b.Fatal(err)
+	}
+	feeCoins := sdk.NewCoins(sdk.NewCoin("tdel", sdk.NewInt(1)))
+	for _, n := range []int{100, 1000} {
+		sends := make([]Send, n)
+		for i := range sends {
+			receiver := make(sdk.AccAddress, sdk.AddrLen)
+			receiver[0], receiver[1] = byte(i), byte(i>>8)
+			sends[i] = Send{Coin: sdk.NewCoin("tdel", sdk.NewInt(int64(i+1))), Receiver: receiver}
+		}
+		msgs := []sdk.Msg{NewMsgMultiSendCoin(sender, sends)}
+		b.Run(fmt.Sprintf("sends=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := api.NewSignedTransaction(msgs, feeCoins, "", account); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
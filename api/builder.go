@@ -0,0 +1,273 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"bitbucket.org/decimalteam/decimal-go-sdk/wallet"
+)
+
+// msgFunc creates message sent by the signer of the transaction.
+type msgFunc func(sender sdk.AccAddress) (sdk.Msg, error)
+
+// TxBuilder builds, signs and broadcasts transactions using chained methods:
+//
+//	result, err := api.NewTxBuilder().Signer(account).Send(to, coin).Memo("payout").Broadcast(ctx)
+//
+// Errors of chained methods are collected and returned by Build, Sign or Broadcast.
+type TxBuilder struct {
+	api *API
+
+	msgs          []msgFunc
+	memo          string
+	feeCoin       string
+	gasAdjustment float64
+	signer        *wallet.Account
+	errs          []error
+}
+
+// NewTxBuilder creates transaction builder.
+// Fee is paid in base coin with gas adjustment 1.0 (exact estimated fee) by default.
+func (api *API) NewTxBuilder() *TxBuilder {
+	return &TxBuilder{api: api, gasAdjustment: 1}
+}
+
+// Signer sets account signing the transaction and sending its messages.
+// Chain ID, account number and sequence are requested from the network when signing if not set up.
+func (b *TxBuilder) Signer(account *wallet.Account) *TxBuilder {
+	b.signer = account
+	return b
+}
+
+// Memo sets transaction memo.
+func (b *TxBuilder) Memo(memo string) *TxBuilder {
+	b.memo = memo
+	return b
+}
+
+// FeeCoin sets symbol of coin used to pay the fee. Fee in custom coin is computed by Build
+// from the estimated fee in base coin and the reserve of the coin.
+func (b *TxBuilder) FeeCoin(symbol string) *TxBuilder {
+	b.feeCoin = strings.ToLower(symbol)
+	return b
+}
+
+// GasAdjustment sets multiplier applied to the estimated gas wanted. It must not be less than 1.
+func (b *TxBuilder) GasAdjustment(adjustment float64) *TxBuilder {
+	if adjustment < 1 {
+		b.errs = append(b.errs, fmt.Errorf("gas adjustment must not be less than 1, got %v", adjustment))
+		return b
+	}
+	b.gasAdjustment = adjustment
+	return b
+}
+
+// Msg appends arbitrary message to the transaction.
+func (b *TxBuilder) Msg(msg sdk.Msg) *TxBuilder {
+	b.msgs = append(b.msgs, func(sdk.AccAddress) (sdk.Msg, error) { return msg, nil })
+	return b
+}
+
+// Send appends message sending coin to the receiver.
+func (b *TxBuilder) Send(to string, coin sdk.Coin) *TxBuilder {
+	b.msgs = append(b.msgs, func(sender sdk.AccAddress) (sdk.Msg, error) {
+		receiver, err := sdk.AccAddressFromBech32(to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid receiver address %q: %w", to, err)
+		}
+		return NewMsgSendCoin(sender, coin, receiver), nil
+	})
+	return b
+}
+
+// MultiSend appends message sending coins to several receivers.
+func (b *TxBuilder) MultiSend(sends ...Send) *TxBuilder {
+	b.msgs = append(b.msgs, func(sender sdk.AccAddress) (sdk.Msg, error) {
+		return NewMsgMultiSendCoin(sender, sends), nil
+	})
+	return b
+}
+
+// Delegate appends message delegating coin to the validator with specified address ("dxvaloper...").
+func (b *TxBuilder) Delegate(validator string, coin sdk.Coin) *TxBuilder {
+	b.msgs = append(b.msgs, func(sender sdk.AccAddress) (sdk.Msg, error) {
+		validatorAddr, err := sdk.ValAddressFromBech32(validator)
+		if err != nil {
+			return nil, fmt.Errorf("invalid validator address %q: %w", validator, err)
+		}
+		return NewMsgDelegate(validatorAddr, sender, coin), nil
+	})
+	return b
+}
+
+// Unbond appends message unbonding coin from the validator with specified address ("dxvaloper...").
+func (b *TxBuilder) Unbond(validator string, coin sdk.Coin) *TxBuilder {
+	b.msgs = append(b.msgs, func(sender sdk.AccAddress) (sdk.Msg, error) {
+		validatorAddr, err := sdk.ValAddressFromBech32(validator)
+		if err != nil {
+			return nil, fmt.Errorf("invalid validator address %q: %w", validator, err)
+		}
+		return NewMsgUnbond(validatorAddr, sender, coin), nil
+	})
+	return b
+}
+
+// maxFeeIterations limits recalculations of the fee in custom coin, which changes the size of the transaction.
+const maxFeeIterations = 8
+
+// Build validates messages and returns unsigned transaction with computed fee.
+// Fee amount is set only for custom fee coin, fee in base coin is deducted by the node.
+func (b *TxBuilder) Build() (auth.StdTx, error) {
+	if len(b.errs) > 0 {
+		return auth.StdTx{}, b.errs[0]
+	}
+	if b.signer == nil {
+		return auth.StdTx{}, errors.New("signer is not set")
+	}
+	if len(b.msgs) == 0 {
+		return auth.StdTx{}, errors.New("transaction contains no messages")
+	}
+	sender, err := sdk.AccAddressFromBech32(b.signer.Address())
+	if err != nil {
+		return auth.StdTx{}, err
+	}
+
+	msgs := make([]sdk.Msg, 0, len(b.msgs))
+	for i, f := range b.msgs {
+		msg, err := f(sender)
+		if err != nil {
+			return auth.StdTx{}, fmt.Errorf("message #%d: %w", i, err)
+		}
+		if err = msg.ValidateBasic(); err != nil {
+			return auth.StdTx{}, fmt.Errorf("message #%d (%s/%s): %w", i, msg.Route(), msg.Type(), err)
+		}
+		msgs = append(msgs, msg)
+	}
+
+	tx := auth.NewStdTx(msgs, auth.NewStdFee(0, nil), nil, b.memo)
+	coin, err := b.customFeeCoin()
+	if err != nil {
+		return auth.StdTx{}, err
+	}
+	if coin == nil {
+		return tx, b.setGas(&tx)
+	}
+	// Fee in custom coin takes bytes of the transaction, so it is increased until it covers the transaction
+	fee := sdk.NewCoin(b.feeCoin, sdk.OneInt())
+	for i := 0; i < maxFeeIterations; i++ {
+		tx.Fee.Amount = sdk.NewCoins(fee)
+		if err = b.setGas(&tx); err != nil {
+			return auth.StdTx{}, err
+		}
+		breakdown, err := b.api.FeeBreakdown(tx)
+		if err != nil {
+			return auth.StdTx{}, err
+		}
+		amount, err := coinSaleAmount(coin, breakdown.Total.Amount)
+		if err != nil {
+			return auth.StdTx{}, err
+		}
+		if amount.LTE(fee.Amount) {
+			return tx, nil
+		}
+		fee.Amount = amount
+	}
+	return auth.StdTx{}, fmt.Errorf("unable to compute fee in coin %q", b.feeCoin)
+}
+
+// customFeeCoin requests the fee coin if it is not base coin, nil is returned for base coin.
+func (b *TxBuilder) customFeeCoin() (*CoinResult, error) {
+	if b.feeCoin == "" {
+		return nil, nil
+	}
+	baseCoin, err := b.api.BaseCoin()
+	if err != nil {
+		return nil, err
+	}
+	if b.feeCoin == baseCoin {
+		return nil, nil
+	}
+	coin, err := b.api.Coin(b.feeCoin)
+	if err != nil && !IsNotFound(err) {
+		return nil, fmt.Errorf("unable to request fee coin %q: %w", b.feeCoin, err)
+	}
+	if coin == nil || coin.Symbol == "" {
+		return nil, fmt.Errorf("fee coin %q does not exist", b.feeCoin)
+	}
+	return coin, nil
+}
+
+// setGas sets gas wanted of the transaction estimated with gas adjustment.
+func (b *TxBuilder) setGas(tx *auth.StdTx) error {
+	tx.Fee.Gas = 0
+	gas, err := b.api.estimateUnsignedGasWanted(*tx)
+	if err != nil {
+		return err
+	}
+	if b.gasAdjustment > 1 {
+		tx.Fee.Gas = uint64(math.Ceil(float64(gas) * b.gasAdjustment))
+		// Adjusted gas may take more bytes in the transaction
+		if gas, err = b.api.EstimateTransactionGasWanted(withPlaceholderSignature(*tx)); err != nil {
+			return err
+		}
+		if gas > tx.Fee.Gas {
+			tx.Fee.Gas = gas
+		}
+	} else {
+		tx.Fee.Gas = gas
+	}
+	return nil
+}
+
+// Sign builds and signs the transaction. Chain ID, account number and sequence of the signer
+// are requested from the network if they are not set up.
+func (b *TxBuilder) Sign() (auth.StdTx, error) {
+	tx, err := b.Build()
+	if err != nil {
+		return auth.StdTx{}, err
+	}
+	if err = b.syncSigner(); err != nil {
+		return auth.StdTx{}, err
+	}
+	return b.signer.SignTransaction(tx)
+}
+
+// Broadcast builds, signs and sends the transaction to the node.
+// Sequence of the signer is incremented if the transaction is accepted.
+func (b *TxBuilder) Broadcast(ctx context.Context) (*BroadcastTxResult, error) {
+	tx, err := b.Sign()
+	if err != nil {
+		return nil, err
+	}
+	result, err := b.api.broadcastTxJSON(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	b.signer.WithSequence(uint64(b.signer.Sequence() + 1))
+	return result, nil
+}
+
+// syncSigner requests chain ID, account number and sequence of the signer if they are not set up.
+func (b *TxBuilder) syncSigner() error {
+	if b.signer.ChainID() == "" {
+		chainID, err := b.api.ChainID()
+		if err != nil {
+			return err
+		}
+		b.signer.WithChainID(chainID)
+	}
+	if b.signer.AccountNumber() < 0 || b.signer.Sequence() < 0 {
+		an, s, err := b.api.AccountNumberAndSequence(b.signer.Address())
+		if err != nil {
+			return err
+		}
+		b.signer.WithAccountNumber(an).WithSequence(s)
+	}
+	return nil
+}
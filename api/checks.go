@@ -0,0 +1,82 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// RedeemedCheckResult contains info about check redeemed in the blockchain.
+type RedeemedCheckResult struct {
+	TxHash   string `json:"txHash"`   // Hash of transaction in which the check was redeemed
+	Height   uint64 `json:"height"`   // Number of block in which the check was redeemed
+	Sender   string `json:"sender"`   // Address of account redeemed the check
+	Issuer   string `json:"issuer"`   // Address of account issued the check
+	Coin     string `json:"coin"`     // Amount and symbol of coin, e.g. "10000tdel"
+	Nonce    string `json:"nonce"`    // Check nonce presented as decimal number
+	DueBlock uint64 `json:"dueBlock"` // Number of block until which the check was valid
+}
+
+// redeemedChecksPerPage is amount of transactions requested per one `tx_search` call.
+const redeemedChecksPerPage = 100
+
+// RedeemedChecks requests full list of checks issued by account with specified address and already redeemed.
+// Gateway: ok, REST/RPC: ok
+func (api *API) RedeemedChecks(issuer string) ([]*RedeemedCheckResult, error) {
+	query := fmt.Sprintf("message.action='redeem_check' AND message.issuer='%s'", issuer)
+	result := []*RedeemedCheckResult{}
+	for page, count := 1, 0; ; page++ {
+		//request
+		found, err := api.rpcTxSearch(query, page, redeemedChecksPerPage, "asc")
+		if err != nil {
+			return nil, err
+		}
+		//process result
+		for _, tx := range found.Txs {
+			if tx.TxResult == nil || tx.TxResult.Code != 0 {
+				continue
+			}
+			height, _ := strconv.ParseUint(tx.Height, 10, 64)
+			for _, event := range tx.TxResult.Events {
+				redeemed := eventToRedeemedCheck(event)
+				if redeemed == nil || redeemed.Issuer != issuer {
+					continue
+				}
+				redeemed.TxHash = tx.Hash
+				redeemed.Height = height
+				result = append(result, redeemed)
+			}
+		}
+		count += len(found.Txs)
+		if len(found.Txs) == 0 || uint64(count) >= uint64(found.TotalCount) {
+			break
+		}
+	}
+	return result, nil
+}
+
+// eventToRedeemedCheck extracts redeemed check info from `message` event emitted by `coin/redeem_check` handler.
+// Returns nil if event does not describe redeemed check.
+func eventToRedeemedCheck(event TxEventBase64) *RedeemedCheckResult {
+	if event.Type != "message" {
+		return nil
+	}
+	redeemed := &RedeemedCheckResult{}
+	for _, attr := range event.Attributes {
+		switch attr.Key {
+		case "sender":
+			redeemed.Sender = attr.Value
+		case "issuer":
+			redeemed.Issuer = attr.Value
+		case "coin":
+			redeemed.Coin = attr.Value
+		case "nonce":
+			redeemed.Nonce = attr.Value
+		case "due_block":
+			redeemed.DueBlock, _ = strconv.ParseUint(attr.Value, 10, 64)
+		}
+	}
+	if redeemed.Issuer == "" || redeemed.Nonce == "" {
+		return nil
+	}
+	return redeemed
+}
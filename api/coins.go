@@ -96,14 +96,26 @@ func (api *API) restCoin(symbol string) (*CoinResult, error) {
 // Coins requests full information about all coins.
 // Gateway: ok, REST/RPC: partial
 func (api *API) Coins() ([]*CoinResult, error) {
+	coins, _, err := api.coinsPage(nil)
+	return coins, err
+}
+
+// CoinsWithOptions requests full information about coins in the page specified by options.
+// Gateway: ok, REST/RPC: partial
+func (api *API) CoinsWithOptions(opts *ListOptions) ([]*CoinResult, error) {
+	coins, _, err := api.coinsPage(opts)
+	return coins, err
+}
+
+func (api *API) coinsPage(opts *ListOptions) ([]*CoinResult, bool, error) {
 	if api.directConn == nil {
-		return api.apiCoins()
+		return api.apiCoins(opts)
 	} else {
-		return api.restCoins()
+		return api.restCoins(opts)
 	}
 }
 
-func (api *API) apiCoins() ([]*CoinResult, error) {
+func (api *API) apiCoins(opts *ListOptions) ([]*CoinResult, bool, error) {
 	type respCoins struct {
 		OK     bool `json:"ok"`
 		Result struct {
@@ -112,9 +124,9 @@ func (api *API) apiCoins() ([]*CoinResult, error) {
 		} `json:"result"`
 	}
 	//request
-	res, err := api.client.rest.R().Get("/coin")
+	res, err := api.client.rest.R().SetQueryParams(opts.gatewayParams()).Get("/coin")
 	if err = processConnectionError(res, err); err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	//json decode
 	respValue, respErr := respCoins{}, Error{}
@@ -122,20 +134,21 @@ func (api *API) apiCoins() ([]*CoinResult, error) {
 		return respValue.OK, respErr.StatusCode != 0
 	})
 	if err != nil {
-		return nil, joinErrors(err, respErr)
+		return nil, false, joinErrors(err, respErr)
 	}
 	//process result
-	return respValue.Result.Coins, nil
+	hasMore := opts.offset()+uint64(len(respValue.Result.Coins)) < respValue.Result.Count
+	return respValue.Result.Coins, hasMore, nil
 }
 
-func (api *API) restCoins() ([]*CoinResult, error) {
+func (api *API) restCoins(opts *ListOptions) ([]*CoinResult, bool, error) {
 	type respDirectCoins struct {
 		Result []string
 	}
 	//request
 	res, err := api.client.rest.R().Get("/coins")
 	if err = processConnectionError(res, err); err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	//json decode
 	respValue := respDirectCoins{}
@@ -143,12 +156,13 @@ func (api *API) restCoins() ([]*CoinResult, error) {
 		return len(respValue.Result) > 0, false
 	})
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	//process result
+	start, end, hasMore := opts.window(len(respValue.Result))
 	coins := []*CoinResult{}
 	errstr := ""
-	for _, val := range respValue.Result {
+	for _, val := range respValue.Result[start:end] {
 		coin, err := api.Coin(val)
 		if err != nil {
 			errstr += err.Error()
@@ -160,5 +174,5 @@ func (api *API) restCoins() ([]*CoinResult, error) {
 	if errstr != "" {
 		err = errors.New(errstr)
 	}
-	return coins, err
+	return coins, hasMore, err
 }
@@ -0,0 +1,193 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"bitbucket.org/decimalteam/decimal-go-sdk/wallet"
+)
+
+////////////////////////////////////////////////////////////////
+// Offline signing workflow:
+//     1. online:  api.NewTxEnvelope(...) -> api.EncodeTxEnvelope(...)
+//     2. offline: api.DecodeTxEnvelope(...) -> api.SignTxEnvelope(...) -> api.EncodeTxEnvelope(...)
+//     3. online:  api.DecodeTxEnvelope(...) -> api.BroadcastTxEnvelope(...)
+// API instance on the offline machine is created as usual (e.g. NewAPIForNetwork),
+// it never connects to the network while signing and estimating fees.
+////////////////////////////////////////////////////////////////
+
+// maxGasIterations limits amount of attempts to adjust gas wanted to the size of the transaction.
+const maxGasIterations = 8
+
+// TxEnvelope contains transaction together with all parameters required to sign it offline.
+type TxEnvelope struct {
+	ChainID       string        `json:"chain_id"`
+	AccountNumber uint64        `json:"account_number"`
+	Sequence      uint64        `json:"sequence"`
+	Signer        string        `json:"signer"` // Address of account which should sign the transaction
+	Tx            auth.StdTx    `json:"tx"`
+	Fee           TxEnvelopeFee `json:"fee"`
+}
+
+// TxEnvelopeFee contains fee of the transaction in units.
+type TxEnvelopeFee struct {
+	Gas           uint64    `json:"gas"`            // Gas wanted, equals to total amount of units
+	BytesUnits    uint64    `json:"bytes_units"`    // Units paid for transaction bytes
+	MessagesUnits []uint64  `json:"messages_units"` // Units paid for each message
+	FeeCoins      sdk.Coins `json:"fee_coins"`      // Coins specified to pay the fee
+}
+
+// Signed returns true if the transaction in the envelope contains signature.
+func (env *TxEnvelope) Signed() bool {
+	return len(env.Tx.Signatures) > 0
+}
+
+// NewTxEnvelope creates unsigned transaction envelope for the signer with specified address.
+// Chain ID, account number and sequence are requested from the network.
+// Gateway: ok, REST/RPC: ok
+func (api *API) NewTxEnvelope(msgs []sdk.Msg, feeCoins sdk.Coins, memo string, signer string) (*TxEnvelope, error) {
+	chainID, err := api.ChainID()
+	if err != nil {
+		return nil, err
+	}
+	accountNumber, sequence, err := api.AccountNumberAndSequence(signer)
+	if err != nil {
+		return nil, err
+	}
+	env := &TxEnvelope{
+		ChainID:       chainID,
+		AccountNumber: accountNumber,
+		Sequence:      sequence,
+		Signer:        signer,
+		Tx:            auth.NewStdTx(msgs, auth.NewStdFee(0, feeCoins), nil, memo),
+	}
+	if err = api.estimateTxEnvelopeFee(env); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// SignTxEnvelope signs transaction in the envelope offline using chain ID, account number
+// and sequence from the envelope. Returns new envelope containing signed transaction.
+func (api *API) SignTxEnvelope(env *TxEnvelope, account *wallet.Account) (*TxEnvelope, error) {
+	if account.Address() != env.Signer {
+		return nil, fmt.Errorf("envelope should be signed by %s, not by %s", env.Signer, account.Address())
+	}
+	account = account.WithChainID(env.ChainID).WithAccountNumber(env.AccountNumber).WithSequence(env.Sequence)
+
+	signed := *env
+	for i := 0; ; i++ {
+		tx := signed.Tx
+		tx.Signatures = nil
+		tx, err := account.SignTransaction(tx)
+		if err != nil {
+			return nil, err
+		}
+		gas, err := api.EstimateTransactionGasWanted(tx)
+		if err != nil {
+			return nil, err
+		}
+		if gas == tx.Fee.Gas {
+			signed.Tx = tx
+			break
+		}
+		// Estimated size of the signature differs from the real one
+		if i == maxGasIterations {
+			return nil, errors.New("unable to adjust gas wanted to the size of signed transaction")
+		}
+		signed.Tx.Fee.Gas = gas
+	}
+	if err := api.fillTxEnvelopeFee(&signed, signed.Tx); err != nil {
+		return nil, err
+	}
+	return &signed, nil
+}
+
+// BroadcastTxEnvelope sends signed transaction from the envelope to the node and returns the result.
+// Gateway: ok, REST/RPC: ok
+func (api *API) BroadcastTxEnvelope(env *TxEnvelope) (*BroadcastTxResult, error) {
+	if !env.Signed() {
+		return nil, errors.New("transaction in the envelope is not signed")
+	}
+	return api.broadcastTxJSON(context.Background(), env.Tx)
+}
+
+// EncodeTxEnvelope encodes the envelope to JSON format.
+func (api *API) EncodeTxEnvelope(env *TxEnvelope) ([]byte, error) {
+	return api.codec.MarshalJSONIndent(env, "", "  ")
+}
+
+// DecodeTxEnvelope decodes the envelope from JSON format.
+func (api *API) DecodeTxEnvelope(data []byte) (*TxEnvelope, error) {
+	env := &TxEnvelope{}
+	if err := api.codec.UnmarshalJSON(data, env); err != nil {
+		return nil, err
+	}
+	if len(env.Tx.Msgs) == 0 {
+		return nil, errors.New("transaction in the envelope contains no messages")
+	}
+	return env, nil
+}
+
+// estimateTxEnvelopeFee sets gas wanted for unsigned transaction in the envelope.
+func (api *API) estimateTxEnvelopeFee(env *TxEnvelope) error {
+	gas, err := api.estimateUnsignedGasWanted(env.Tx)
+	if err != nil {
+		return err
+	}
+	env.Tx.Fee.Gas = gas
+	return api.fillTxEnvelopeFee(env, withPlaceholderSignature(env.Tx))
+}
+
+// estimateUnsignedGasWanted returns gas wanted for the transaction after it is signed by single account.
+// Since sizes of secp256k1 public key and signature are fixed, the transaction is measured
+// with placeholder signature, so the fee remains the same after signing.
+func (api *API) estimateUnsignedGasWanted(tx auth.StdTx) (uint64, error) {
+	tx = withPlaceholderSignature(tx)
+	for i := 0; ; i++ {
+		gas, err := api.EstimateTransactionGasWanted(tx)
+		if err != nil {
+			return 0, err
+		}
+		if gas == tx.Fee.Gas {
+			return gas, nil
+		}
+		if i == maxGasIterations {
+			return 0, errors.New("unable to adjust gas wanted to the size of transaction")
+		}
+		tx.Fee.Gas = gas
+	}
+}
+
+// fillTxEnvelopeFee fills fee of the envelope with units counted for the (signed) transaction.
+func (api *API) fillTxEnvelopeFee(env *TxEnvelope, tx auth.StdTx) error {
+	bytesFee, msgsFees, err := api.getFeeUnits(tx)
+	if err != nil {
+		return err
+	}
+	env.Fee = TxEnvelopeFee{
+		Gas:           env.Tx.Fee.Gas,
+		BytesUnits:    uint64(bytesFee),
+		MessagesUnits: make([]uint64, len(msgsFees)),
+		FeeCoins:      env.Tx.Fee.Amount,
+	}
+	for i, fee := range msgsFees {
+		env.Fee.MessagesUnits[i] = uint64(fee)
+	}
+	return nil
+}
+
+// withPlaceholderSignature returns copy of the transaction containing signature
+// with the same size as real secp256k1 signature.
+func withPlaceholderSignature(tx auth.StdTx) auth.StdTx {
+	tx.Signatures = []auth.StdSignature{{
+		PubKey:    secp256k1.PubKeySecp256k1{},
+		Signature: make([]byte, 64),
+	}}
+	return tx
+}
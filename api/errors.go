@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"strings"
 
 	"github.com/go-resty/resty/v2"
 )
@@ -102,6 +104,36 @@ func (e JsonRPCInternalError) Error() string {
 // Error for queries without RPC/REST implementation
 var ErrNotImplemented = errors.New("not implemented")
 
+// Error for requested items which do not exist (e.g. NFT looked up in all collections)
+var ErrNotFound = errors.New("not found")
+
+// IsNotFound checks that the error means requested item does not exist rather than
+// the request failed: ErrNotFound, HTTP 404 or error of the node querier about missing item.
+func IsNotFound(err error) bool {
+	var (
+		apiErr  Error
+		rpcErr  JsonRPCInternalError
+		respErr *ResponseError
+	)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return true
+	case errors.As(err, &apiErr):
+		return apiErr.StatusCode == http.StatusNotFound
+	case errors.As(err, &rpcErr):
+		return rpcErr.Code == http.StatusNotFound
+	case errors.As(err, &respErr):
+		if respErr.StatusCode() == http.StatusNotFound {
+			return true
+		}
+		// node REST returns querier errors as internal server error
+		body := respErr.String()
+		return respErr.StatusCode() == http.StatusInternalServerError &&
+			(strings.Contains(body, "does not exist") || strings.Contains(body, "not found"))
+	}
+	return false
+}
+
 // Error indicating for universal decoding
 var ErrIsRPCError = errors.New("rpc error")
 var ErrMissing = errors.New("universal JSON decode missing logic")
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"bitbucket.org/decimalteam/go-node/utils/formulas"
+)
+
+// FeeBreakdown contains itemized fee of the transaction.
+type FeeBreakdown struct {
+	Bytes       int              `json:"bytes"`         // Size of the (signed) transaction in bytes
+	BytesUnits  Fee              `json:"bytes_units"`   // Units paid for transaction bytes
+	Messages    []MessageFeeItem `json:"messages"`      // Units paid for each message
+	SpecialFees []SpecialFeeItem `json:"special_fees"`  // Coins paid for messages only when transaction is executed successfully
+	TotalUnits  Fee              `json:"total_units"`   // Units paid for bytes and messages, equals to gas wanted
+	Total       sdk.Coin         `json:"total"`         // Total units in base coin (pip)
+	TotalInCoin *sdk.Coin        `json:"total_in_coin"` // Total units in the fee coin (pip), only if requested
+}
+
+// MessageFeeItem contains fee of single message in units.
+type MessageFeeItem struct {
+	Msg   int    `json:"msg"`  // Index of the message in the transaction
+	Name  string `json:"name"` // Route and type of the message (like "coin/send_coin")
+	Units Fee    `json:"units"`
+}
+
+// SpecialFeeItem contains special fee of single message (e.g. coin creation fee).
+type SpecialFeeItem struct {
+	Msg  int      `json:"msg"`  // Index of the message in the transaction
+	Name string   `json:"name"` // Route and type of the message (like "coin/create_coin")
+	Coin sdk.Coin `json:"coin"`
+}
+
+// FeeBreakdown returns itemized fee of the transaction in units and in base coin.
+// Unsigned transaction is measured as signed by single account (with estimated gas wanted if it is not set),
+// so the fee is the same after signing.
+// Gateway: ok, REST/RPC: ok
+func (api *API) FeeBreakdown(tx auth.StdTx) (*FeeBreakdown, error) {
+	if len(tx.Signatures) == 0 {
+		if tx.Fee.Gas == 0 {
+			gas, err := api.estimateUnsignedGasWanted(tx)
+			if err != nil {
+				return nil, err
+			}
+			tx.Fee.Gas = gas
+		}
+		tx = withPlaceholderSignature(tx)
+	}
+	baseCoin, err := api.BaseCoin()
+	if err != nil {
+		return nil, err
+	}
+	txBytes, err := api.codec.MarshalBinaryLengthPrefixed(tx)
+	if err != nil {
+		return nil, err
+	}
+	bytesUnits, msgsUnits, err := api.getFeeUnits(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	breakdown := &FeeBreakdown{
+		Bytes:       len(txBytes),
+		BytesUnits:  bytesUnits,
+		Messages:    make([]MessageFeeItem, len(tx.Msgs)),
+		SpecialFees: []SpecialFeeItem{},
+		TotalUnits:  bytesUnits,
+	}
+	for i, msg := range tx.Msgs {
+		name := fmt.Sprintf("%s/%s", msg.Route(), msg.Type())
+		breakdown.Messages[i] = MessageFeeItem{Msg: i, Name: name, Units: msgsUnits[i]}
+		breakdown.TotalUnits += msgsUnits[i]
+
+		payment, err := api.getMessageSpecialFee(msg)
+		if err != nil {
+			return nil, err
+		}
+		if payment.Denom != "" {
+			breakdown.SpecialFees = append(breakdown.SpecialFees, SpecialFeeItem{Msg: i, Name: name, Coin: payment})
+		}
+	}
+	// Gas wanted is integer amount of units
+	breakdown.Total = sdk.NewCoin(baseCoin, sdk.NewInt(int64(breakdown.TotalUnits)).Mul(unitInPip))
+	return breakdown, nil
+}
+
+// FeeBreakdownInCoin returns itemized fee of the transaction like FeeBreakdown
+// together with the amount of the fee coin equivalent to the total in base coin.
+// Gateway: ok, REST/RPC: ok
+func (api *API) FeeBreakdownInCoin(tx auth.StdTx, feeCoin string) (*FeeBreakdown, error) {
+	breakdown, err := api.FeeBreakdown(tx)
+	if err != nil {
+		return nil, err
+	}
+	feeCoin = strings.ToLower(feeCoin)
+	if feeCoin == breakdown.Total.Denom {
+		total := breakdown.Total
+		breakdown.TotalInCoin = &total
+		return breakdown, nil
+	}
+	coin, err := api.Coin(feeCoin)
+	if err != nil {
+		return nil, err
+	}
+	amount, err := coinSaleAmount(coin, breakdown.Total.Amount)
+	if err != nil {
+		return nil, err
+	}
+	total := sdk.NewCoin(feeCoin, amount)
+	breakdown.TotalInCoin = &total
+	return breakdown, nil
+}
+
+// coinSaleAmount returns amount of the custom coin which should be sold to receive specified amount of base coin.
+func coinSaleAmount(coin *CoinResult, wantReceive sdk.Int) (sdk.Int, error) {
+	volume, err := coin.VolumeAmount()
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	reserve, err := coin.ReserveAmount()
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	if reserve.Pip().LTE(wantReceive) {
+		return sdk.Int{}, fmt.Errorf("reserve of coin %q is not sufficient to pay %s pip of base coin", coin.Symbol, wantReceive)
+	}
+	amount := formulas.CalculateSaleAmount(volume.Pip(), reserve.Pip(), uint(coin.Crr), wantReceive)
+	// Node checks fee with sale return formula, so compensate rounding
+	if formulas.CalculateSaleReturn(volume.Pip(), reserve.Pip(), uint(coin.Crr), amount).LT(wantReceive) {
+		amount = amount.AddRaw(1)
+	}
+	return amount, nil
+}
@@ -13,6 +13,7 @@ import (
 //     1 unit  =  10^15 pip  =  0.001 DEL
 // E.g. MsgSendCoin fee is 10 units.
 // Also sender should pay extra 2 units per byte in raw tx.
+// Fees of the messages are listed in the fee schedule, see FeeSchedule.
 ////////////////////////////////////////////////////////////////
 
 // Fee is an alias of the type of variables containing amount of units in a value.
@@ -21,6 +22,9 @@ type Fee float64
 // Unit defines cost of 1 unit in DEL.
 const unit Fee = 0.001
 
+// unitInPip defines cost of 1 unit in pip.
+var unitInPip = sdk.NewInt(1_000_000_000_000_000)
+
 // Fees for `coin/*` messages.
 const (
 	FeeCoinCreate      Fee = 100
@@ -31,6 +35,7 @@ const (
 	FeeCoinSell        Fee = 100
 	FeeCoinSellAll     Fee = 100
 	FeeCoinRedeemCheck Fee = 30
+	FeeCoinBurn        Fee = 10
 )
 
 // Fees for `multisig/*` messages.
@@ -52,12 +57,20 @@ const (
 
 // Fees for `nft/*` messages.
 const (
-	FeeNFTMint         Fee = 0
-	FeeNFTBurn         Fee = 0
-	FeeNFTTransfer     Fee = 0
-	FeeNFTDelegate     Fee = 0
-	FeeNFTUnbound      Fee = 0
-	FeeNFTEditMetadata Fee = 0
+	FeeNFTMint          Fee = 0
+	FeeNFTBurn          Fee = 0
+	FeeNFTTransfer      Fee = 0
+	FeeNFTDelegate      Fee = 0
+	FeeNFTUnbound       Fee = 0
+	FeeNFTEditMetadata  Fee = 0
+	FeeNFTUpdateReserve Fee = 0
+)
+
+// Fees for `gov/*` messages.
+const (
+	FeeGovSubmitProposal  Fee = 0
+	FeeGovVote            Fee = 0
+	FeeGovSoftwareUpgrade Fee = 0
 )
 
 // Fees for `swap/*` messages.
@@ -73,126 +86,23 @@ const (
 
 // EstimateTransactionGasWanted counts complete set of different fees and
 // returns exact gas wanted to successfully execute specified transaction.
+// Fees are counted using fee schedule of the API instance, see API.FeeSchedule.
 func (api *API) EstimateTransactionGasWanted(tx auth.StdTx) (uint64, error) {
-	gasWanted, err := api.getTransactionFee(tx)
+	gasWanted, msgsFees, err := api.getFeeUnits(tx)
 	if err != nil {
 		return 0, err
 	}
-	for _, msg := range tx.Msgs {
-		fee, err := api.getMessageFee(msg)
-		if err != nil {
-			return 0, err
-		}
+	for _, fee := range msgsFees {
 		gasWanted += fee
 	}
 	return uint64(gasWanted), nil
 }
 
 // getMessageFee returns amount of fixed units needed to pay for the specified message.
-func (api *API) getMessageFee(msg sdk.Msg) (fee Fee, err error) {
-	// TODO: api object not used. Method -> Function.
-	switch r := msg.Route(); r {
-	case "nft":
-		switch t := msg.Type(); t {
-		case "burn_nft":
-			fee = FeeNFTBurn
-		case "mint_nft":
-			fee = FeeNFTMint
-		case "edit_nft_metadata":
-			fee = FeeNFTEditMetadata
-		case "transfer_nft":
-			fee = FeeNFTTransfer
-		default:
-			err = fmt.Errorf(`unexpected message "nft/%s"`, t)
-		}
-	case "swap":
-		switch t := msg.Type(); t {
-		case "htlt":
-			fee = FeeSwapHTLT
-		case "redeem":
-			fee = FeeSwapRedeem
-		case "refund":
-			fee = FeeSwapRefund
-		case "swap_initialize":
-			fee = FeeSwapInitialize
-		case "redeem_v2":
-			fee = FeeSwapRedeemV2
-		case "chain_activate":
-			fee = FeeChainActivate
-		case "chain_deactivate":
-			fee = FeeSwapDeactivate
-		default:
-			err = fmt.Errorf(`unexpected message "swap/%s"`, t)
-		}
-	case "coin":
-		switch t := msg.Type(); t {
-		case "create_coin":
-			fee = FeeCoinCreate
-		case "update_coin":
-			fee = FeeCoinUpdate
-		case "send_coin":
-			fee = FeeCoinSend
-		case "multi_send_coin":
-			msgMultiSendCoin, ok := msg.(*MsgMultiSendCoin)
-			if !ok {
-				err = fmt.Errorf("unable to cast message to type *MsgMultiSendCoin")
-				return
-			}
-			fee = FeeCoinMultiSend + Fee(len(msgMultiSendCoin.Sends)-1)*5
-		case "buy_coin":
-			fee = FeeCoinBuy
-		case "sell_coin":
-			fee = FeeCoinSell
-		case "sell_all_coin":
-			fee = FeeCoinSellAll
-		case "redeem_check":
-			fee = FeeCoinRedeemCheck
-		default:
-			err = fmt.Errorf(`unexpected message "coin/%s"`, t)
-		}
-	case "multisig":
-		switch t := msg.Type(); t {
-		case "create_wallet":
-			fee = FeeMultisigCreateWallet
-		case "create_transaction":
-			fee = FeeMultisigCreateTransaction
-		case "sign_transaction":
-			fee = FeeMultisigSignTransaction
-		default:
-			err = fmt.Errorf(`unexpected message "multisig/%s"`, t)
-		}
-	case "validator":
-		switch t := msg.Type(); t {
-		case "declare_candidate":
-			fee = FeeValidatorDeclareCandidate
-		case "edit_candidate":
-			fee = FeeValidatorEditCandidate
-		case "delegate":
-			fee = FeeValidatorDelegate
-		case "unbond":
-			fee = FeeValidatorUnbond
-		case "set_online":
-			fee = FeeValidatorSetOnline
-		case "set_offline":
-			fee = FeeValidatorSetOffline
-		case "delegate_nft":
-			fee = FeeNFTDelegate
-		case "unbond_nft":
-			fee = FeeNFTUnbound
-		default:
-			err = fmt.Errorf(`unexpected message "validator/%s"`, t)
-		}
-	case "gov":
-		fee = 0
-	default:
-		err = fmt.Errorf(`unexpected message route "%s"`, r)
-	}
-	return
+func (api *API) getMessageFee(msg sdk.Msg) (Fee, error) {
+	return api.FeeSchedule().MessageFee(msg)
 }
 
-// e18 is a multiply factor to convert value in coin (like DEL) to value in pip.
-var e18 = sdk.NewInt(1000000000000000000)
-
 // getMessageSpecialFee returns amount of coins needed to pay for the specified message.
 // NOTE: This payment is not counted as gas or units. It is a fee in base or custom coin.
 // It is spent only when transaction was successfully executed. In case of failure this
@@ -201,15 +111,25 @@ var e18 = sdk.NewInt(1000000000000000000)
 func (api *API) getMessageSpecialFee(msg sdk.Msg) (payment sdk.Coin, err error) {
 	msgID := fmt.Sprintf("%s/%s", msg.Route(), msg.Type())
 
+	baseCoin, err := api.BaseCoin()
+	if err != nil {
+		return
+	}
+
 	// Special fee for "coin/create_coin" message
 	if msgID == "coin/create_coin" {
-		msgCreateCoin, ok := msg.(*MsgCreateCoin)
-		if !ok {
-			err = fmt.Errorf("unable to cast message to type *MsgCreateCoin")
+		var symbol string
+		switch m := msg.(type) {
+		case MsgCreateCoin:
+			symbol = m.Symbol
+		case *MsgCreateCoin:
+			symbol = m.Symbol
+		default:
+			err = fmt.Errorf("unable to cast message to type MsgCreateCoin")
 			return
 		}
 		var amountInBaseCoin sdk.Int
-		switch len(msgCreateCoin.Symbol) {
+		switch len(symbol) {
 		case 3:
 			amountInBaseCoin = sdk.NewInt(1_000_000)
 		case 4:
@@ -221,18 +141,33 @@ func (api *API) getMessageSpecialFee(msg sdk.Msg) (payment sdk.Coin, err error)
 		default:
 			amountInBaseCoin = sdk.NewInt(100)
 		}
-		payment = sdk.NewCoin(BaseCoinSymbol, amountInBaseCoin.Mul(e18))
+		payment = sdk.NewCoin(baseCoin, amountInBaseCoin.Mul(e18))
 	}
 
 	return
 }
 
-// getTransactionFee returns amount of units needed to pay for transaction bytes (2 units for each byte).
+// getTransactionFee returns amount of units needed to pay for transaction bytes (2 units for each byte by default).
 func (api *API) getTransactionFee(tx auth.StdTx) (fee Fee, err error) {
 	txBytes, err := api.codec.MarshalBinaryLengthPrefixed(tx)
 	if err != nil {
 		return
 	}
-	fee = Fee(len(txBytes)) * 2
+	fee = Fee(len(txBytes)) * api.FeeSchedule().BytesUnits
+	return
+}
+
+// getFeeUnits returns amount of units needed to pay for transaction bytes and for each message.
+func (api *API) getFeeUnits(tx auth.StdTx) (bytesFee Fee, msgsFees []Fee, err error) {
+	bytesFee, err = api.getTransactionFee(tx)
+	if err != nil {
+		return
+	}
+	msgsFees = make([]Fee, len(tx.Msgs))
+	for i, msg := range tx.Msgs {
+		if msgsFees[i], err = api.getMessageFee(msg); err != nil {
+			return
+		}
+	}
 	return
 }
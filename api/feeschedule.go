@@ -0,0 +1,204 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+////////////////////////////////////////////////////////////////
+// Fee schedule lists fees of all messages registered in the codec
+// by their codec names (like "coin/send_coin").
+// Decimal node v1.3.0 does not store fees in module params, they are
+// constants of the node ante handler. DefaultFeeSchedule mirrors them
+// and can be overridden by JSON file when the network changes fees:
+//     {
+//       "bytes_units": 2,
+//       "messages": {
+//         "coin/send_coin": {"units": 10},
+//         "coin/multi_send_coin": {"units": 10, "extra_units_per_send": 5}
+//       }
+//     }
+// Messages missing in the file keep their default fees.
+////////////////////////////////////////////////////////////////
+
+// MsgFee contains fee of the message in units.
+type MsgFee struct {
+	Units             Fee `json:"units"`
+	ExtraUnitsPerSend Fee `json:"extra_units_per_send,omitempty"` // Only for "coin/multi_send_coin": units for each send except first one
+}
+
+// FeeSchedule contains fees of transactions in units.
+type FeeSchedule struct {
+	BytesUnits Fee               `json:"bytes_units"` // Units paid for each byte of the transaction
+	Messages   map[string]MsgFee `json:"messages"`    // Fees of messages by their codec names
+}
+
+// codecMsgNames maps types of messages registered in the codec to their codec names.
+var codecMsgNames = func() map[reflect.Type]string {
+	names := make(map[reflect.Type]string, len(codecMsgs))
+	for _, m := range codecMsgs {
+		names[reflect.TypeOf(m.msg)] = m.name
+	}
+	return names
+}()
+
+// DefaultFeeSchedule returns fee schedule used by Decimal node.
+func DefaultFeeSchedule() *FeeSchedule {
+	return &FeeSchedule{
+		BytesUnits: 2,
+		Messages: map[string]MsgFee{
+			"coin/create_coin":     {Units: FeeCoinCreate},
+			"coin/send_coin":       {Units: FeeCoinSend},
+			"coin/multi_send_coin": {Units: FeeCoinMultiSend, ExtraUnitsPerSend: 5},
+			"coin/buy_coin":        {Units: FeeCoinBuy},
+			"coin/sell_coin":       {Units: FeeCoinSell},
+			"coin/sell_all_coin":   {Units: FeeCoinSellAll},
+			"coin/update_coin":     {Units: FeeCoinUpdate},
+			"coin/redeem_check":    {Units: FeeCoinRedeemCheck},
+			"coin/burn_coin":       {Units: FeeCoinBurn},
+
+			"validator/declare_candidate": {Units: FeeValidatorDeclareCandidate},
+			"validator/delegate":          {Units: FeeValidatorDelegate},
+			"validator/set_online":        {Units: FeeValidatorSetOnline},
+			"validator/set_offline":       {Units: FeeValidatorSetOffline},
+			"validator/unbond":            {Units: FeeValidatorUnbond},
+			"validator/edit_candidate":    {Units: FeeValidatorEditCandidate},
+			"validator/delegate_nft":      {Units: FeeNFTDelegate},
+			"validator/unbond_nft":        {Units: FeeNFTUnbound},
+
+			"nft/msg_burn":          {Units: FeeNFTBurn},
+			"nft/msg_mint":          {Units: FeeNFTMint},
+			"nft/msg_edit_metadata": {Units: FeeNFTEditMetadata},
+			"nft/msg_transfer":      {Units: FeeNFTTransfer},
+			"nft/update_reserve":    {Units: FeeNFTUpdateReserve},
+
+			"swap/msg_htlt":             {Units: FeeSwapHTLT},
+			"swap/msg_redeem":           {Units: FeeSwapRedeem},
+			"swap/msg_refund":           {Units: FeeSwapRefund},
+			"swap/msg_initialize":       {Units: FeeSwapInitialize},
+			"swap/msg_redeem_v2":        {Units: FeeSwapRedeemV2},
+			"swap/msg_chain_activate":   {Units: FeeChainActivate},
+			"swap/msg_chain_deactivate": {Units: FeeSwapDeactivate},
+
+			"multisig/create_wallet":      {Units: FeeMultisigCreateWallet},
+			"multisig/create_transaction": {Units: FeeMultisigCreateTransaction},
+			"multisig/sign_transaction":   {Units: FeeMultisigSignTransaction},
+
+			"cosmos-sdk/MsgSubmitProposal":          {Units: FeeGovSubmitProposal},
+			"cosmos-sdk/MsgVote":                    {Units: FeeGovVote},
+			"cosmos-sdk/MsgSoftwareUpgradeProposal": {Units: FeeGovSoftwareUpgrade},
+		},
+	}
+}
+
+// ParseFeeSchedule parses fee schedule from JSON. Fees missing in JSON are taken from DefaultFeeSchedule.
+func ParseFeeSchedule(data []byte) (*FeeSchedule, error) {
+	parsed := FeeSchedule{}
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("unable to parse fee schedule: %w", err)
+	}
+	schedule := DefaultFeeSchedule()
+	if parsed.BytesUnits != 0 {
+		schedule.BytesUnits = parsed.BytesUnits
+	}
+	for name, fee := range parsed.Messages {
+		schedule.Messages[name] = fee
+	}
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// LoadFeeSchedule reads fee schedule from JSON file, see ParseFeeSchedule.
+func LoadFeeSchedule(path string) (*FeeSchedule, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFeeSchedule(data)
+}
+
+// Validate checks that the schedule contains fees of all messages registered in the codec,
+// does not contain unknown messages and all fees are not negative.
+func (s *FeeSchedule) Validate() error {
+	var problems []string
+	if s.BytesUnits < 0 {
+		problems = append(problems, "negative bytes units")
+	}
+	known := make(map[string]bool, len(codecMsgs))
+	for _, m := range codecMsgs {
+		known[m.name] = true
+		if _, ok := s.Messages[m.name]; !ok {
+			problems = append(problems, fmt.Sprintf("missing fee of %q", m.name))
+		}
+	}
+	for name, fee := range s.Messages {
+		if !known[name] {
+			problems = append(problems, fmt.Sprintf("unknown message %q", name))
+		}
+		if fee.Units < 0 || fee.ExtraUnitsPerSend < 0 {
+			problems = append(problems, fmt.Sprintf("negative fee of %q", name))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return errors.New(strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// MessageFee returns amount of units needed to pay for the message.
+func (s *FeeSchedule) MessageFee(msg sdk.Msg) (Fee, error) {
+	t := reflect.TypeOf(msg)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	name, ok := codecMsgNames[t]
+	if !ok {
+		return 0, fmt.Errorf(`unexpected message "%s/%s"`, msg.Route(), msg.Type())
+	}
+	fee, ok := s.Messages[name]
+	if !ok {
+		return 0, fmt.Errorf(`fee of message "%s" is not specified`, name)
+	}
+	units := fee.Units
+	if fee.ExtraUnitsPerSend != 0 {
+		var sends int
+		switch m := msg.(type) {
+		case MsgMultiSendCoin:
+			sends = len(m.Sends)
+		case *MsgMultiSendCoin:
+			sends = len(m.Sends)
+		}
+		if sends > 1 {
+			units += Fee(sends-1) * fee.ExtraUnitsPerSend
+		}
+	}
+	return units, nil
+}
+
+// FeeSchedule returns fee schedule used to estimate fees of transactions.
+func (api *API) FeeSchedule() *FeeSchedule {
+	api.mtx.RLock()
+	defer api.mtx.RUnlock()
+	return api.fees
+}
+
+// SetFeeSchedule sets fee schedule used to estimate fees of transactions.
+func (api *API) SetFeeSchedule(schedule *FeeSchedule) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+	api.mtx.Lock()
+	defer api.mtx.Unlock()
+	api.fees = schedule
+	return nil
+}
@@ -0,0 +1,22 @@
+package api
+
+import (
+	"testing"
+)
+
+// TestDefaultFeeScheduleCoversCodec fails when a message is registered in the codec without a fee.
+func TestDefaultFeeScheduleCoversCodec(t *testing.T) {
+	schedule := DefaultFeeSchedule()
+	for _, m := range codecMsgs {
+		if _, ok := schedule.Messages[m.name]; !ok {
+			t.Errorf("message %q is registered in the codec without fee in DefaultFeeSchedule", m.name)
+			continue
+		}
+		if _, err := schedule.MessageFee(m.msg); err != nil {
+			t.Errorf("fee of message %q: %s", m.name, err.Error())
+		}
+	}
+	if err := schedule.Validate(); err != nil {
+		t.Errorf("default fee schedule is invalid: %s", err.Error())
+	}
+}
@@ -1,8 +1,13 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // ProposalResponse contains API response.
@@ -41,38 +46,102 @@ type ProposalResult struct {
 	PercentNone      string    `json:"percentNone"`
 	Votes            struct {
 		Count int `json:"count"`
-		Votes []struct {
-			ID          int64     `json:"id"`
-			HashTx      string    `json:"hashTx"` // Hash of transaction in which the proposal was created
-			ValidatorId string    `json:"validatorId"`
-			Stake       float64   `json:"stake"`
-			Vote        string    `json:"vote"`
-			CreatedAt   time.Time `json:"createdAt"`
-			UpdatedAt   time.Time `json:"updatedAt"`
-		}
+		Votes []ProposalVote
 	} `json:"votes"`
 
 	HashTx string `json:"hashTx"` // Hash of transaction in which the proposal was created
+
+	// Exact stakes in pip (StakesTotal, StakesYes, StakesNo and StakesAbstain are lossy float64 values)
+	StakesTotalPip   sdk.Int `json:"-"`
+	StakesYesPip     sdk.Int `json:"-"`
+	StakesNoPip      sdk.Int `json:"-"`
+	StakesAbstainPip sdk.Int `json:"-"`
+}
+
+// ProposalVote contains API response fields.
+type ProposalVote struct {
+	ID          int64     `json:"id"`
+	HashTx      string    `json:"hashTx"` // Hash of transaction in which the proposal was created
+	ValidatorId string    `json:"validatorId"`
+	Stake       float64   `json:"stake"`
+	Vote        string    `json:"vote"` // Vote option ("Yes", "No", "Abstain")
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// ProposalTally contains stakes (in pip) of validators voted for the proposal.
+type ProposalTally struct {
+	Yes     sdk.Int `json:"yes"`
+	Abstain sdk.Int `json:"abstain"`
+	No      sdk.Int `json:"no"`
+}
+
+// Total returns total stake of voted validators.
+func (t ProposalTally) Total() sdk.Int {
+	return t.Yes.Add(t.Abstain).Add(t.No)
+}
+
+// UnmarshalJSON implements Unmarshaler interface.
+// Stakes are parsed both to float64 fields and to exact sdk.Int fields.
+func (r *ProposalResult) UnmarshalJSON(b []byte) error {
+	type proposalResult ProposalResult
+	raw := struct {
+		*proposalResult
+		StakesTotal   json.RawMessage `json:"stakesTotal"`
+		StakesYes     json.RawMessage `json:"stakesYes"`
+		StakesNo      json.RawMessage `json:"stakesNo"`
+		StakesAbstain json.RawMessage `json:"stakesAbstain"`
+	}{proposalResult: (*proposalResult)(r)}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	for _, stake := range []struct {
+		raw   json.RawMessage
+		float *float64
+		pip   *sdk.Int
+	}{
+		{raw.StakesTotal, &r.StakesTotal, &r.StakesTotalPip},
+		{raw.StakesYes, &r.StakesYes, &r.StakesYesPip},
+		{raw.StakesNo, &r.StakesNo, &r.StakesNoPip},
+		{raw.StakesAbstain, &r.StakesAbstain, &r.StakesAbstainPip},
+	} {
+		pip, err := parseJSONAmount(stake.raw)
+		if err != nil {
+			return err
+		}
+		*stake.pip = pip
+		*stake.float, _ = strconv.ParseFloat(strings.Trim(string(stake.raw), `"`), 64)
+	}
+	return nil
 }
 
 // Proposals requests full information about all govs.
-// Gateway: ok, RPC/REST: none
+// Gateway: ok, RPC/REST: partial
 func (api *API) Proposals() ([]ProposalResult, error) {
-	var (
-		url = ""
-	)
+	proposals, _, err := api.proposalsPage(nil)
+	return proposals, err
+}
 
-	// TODO: test with directConn.
+// ProposalsWithOptions requests full information about govs in the page specified by options.
+// Gateway: ok, RPC/REST: partial
+func (api *API) ProposalsWithOptions(opts *ListOptions) ([]ProposalResult, error) {
+	proposals, _, err := api.proposalsPage(opts)
+	return proposals, err
+}
+
+func (api *API) proposalsPage(opts *ListOptions) ([]ProposalResult, bool, error) {
 	if api.directConn == nil {
-		url = "/proposals"
+		return api.apiProposals(opts)
 	} else {
-		return nil, ErrNotImplemented
-		url = "/gov/proposals"
+		return api.restProposals(opts)
 	}
+}
+
+func (api *API) apiProposals(opts *ListOptions) ([]ProposalResult, bool, error) {
 	//request
-	res, err := api.client.rest.R().Get(url)
+	res, err := api.client.rest.R().SetQueryParams(opts.gatewayParams()).Get("/proposals")
 	if err = processConnectionError(res, err); err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	//json decode
 	respValue, respErr := ProposalsResponse{}, Error{}
@@ -80,29 +149,106 @@ func (api *API) Proposals() ([]ProposalResult, error) {
 		return respValue.OK, respErr.StatusCode != 0
 	})
 	if err != nil {
-		return nil, joinErrors(err, respErr)
+		return nil, false, joinErrors(err, respErr)
 	}
 	//process result
-	return respValue.Result.Proposals, nil
+	hasMore := opts.offset()+uint64(len(respValue.Result.Proposals)) < respValue.Result.Count
+	return respValue.Result.Proposals, hasMore, nil
+}
+
+type respDirectProposal struct {
+	Content struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+	} `json:"Content"`
+	ProposalID       int64         `json:"id,string"`
+	Status           string        `json:"proposal_status"`
+	FinalTallyResult ProposalTally `json:"final_tally_result"`
+	VotingStartBlock string        `json:"voting_start_time"`
+	VotingEndBlock   string        `json:"voting_end_time"`
+}
+
+type respDirectVote struct {
+	Voter  string `json:"voter"`
+	Option string `json:"option"`
+}
+
+// directResponse2Proposal converts node proposal with the tally (final or current) to API result.
+func directResponse2Proposal(dres respDirectProposal, tally ProposalTally) ProposalResult {
+	result := ProposalResult{
+		ProposalID:       dres.ProposalID,
+		Title:            dres.Content.Title,
+		Description:      dres.Content.Description,
+		VotingStartBlock: dres.VotingStartBlock,
+		VotingEndBlock:   dres.VotingEndBlock,
+		Status:           dres.Status,
+	}
+	for _, stake := range []struct {
+		value sdk.Int
+		float *float64
+		pip   *sdk.Int
+	}{
+		{tally.Total(), &result.StakesTotal, &result.StakesTotalPip},
+		{tally.Yes, &result.StakesYes, &result.StakesYesPip},
+		{tally.No, &result.StakesNo, &result.StakesNoPip},
+		{tally.Abstain, &result.StakesAbstain, &result.StakesAbstainPip},
+	} {
+		*stake.pip = stake.value
+		*stake.float, _ = strconv.ParseFloat(stake.value.String(), 64)
+	}
+	return result
+}
+
+func (api *API) restProposals(opts *ListOptions) ([]ProposalResult, bool, error) {
+	//request
+	req := api.client.rest.R()
+	skip := uint64(0)
+	if opts != nil {
+		var page, limit uint64
+		page, limit, skip = opts.restPage()
+		req.SetQueryParam("page", strconv.FormatUint(page, 10))
+		req.SetQueryParam("limit", strconv.FormatUint(limit, 10))
+	}
+	res, err := req.Get("/gov/proposals")
+	if err = processConnectionError(res, err); err != nil {
+		return nil, false, err
+	}
+	//json decode
+	respValue := struct {
+		Result []respDirectProposal `json:"result"`
+	}{}
+	err = universalJSONDecode(res.Body(), &respValue, nil, func() (bool, bool) {
+		return true, false
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	//process result
+	proposals := []ProposalResult{}
+	for i, p := range respValue.Result {
+		if uint64(i) < skip {
+			continue
+		}
+		proposals = append(proposals, directResponse2Proposal(p, normalizeTally(p.FinalTallyResult)))
+	}
+	// node does not return total count, so full page means there may be more items
+	hasMore := opts != nil && uint64(len(respValue.Result)) == opts.limit()
+	return proposals, hasMore, nil
 }
 
 // Proposal requests full information about gov with specified id.
-// Gateway: ok, RPC/REST: none
+// Gateway: ok, RPC/REST: partial (proposer and percents are not available)
 func (api *API) Proposal(id int64) (ProposalResult, error) {
-	var (
-		url = ""
-	)
-
-	// TODO: test with directConn.
 	if api.directConn == nil {
-		url = fmt.Sprintf("/proposalById/%d", id)
+		return api.apiProposal(id)
 	} else {
-		return ProposalResult{}, ErrNotImplemented
-		url = fmt.Sprintf("/gov/proposals/%d", id)
+		return api.restProposal(id)
 	}
+}
 
+func (api *API) apiProposal(id int64) (ProposalResult, error) {
 	//request
-	res, err := api.client.rest.R().Get(url)
+	res, err := api.client.rest.R().Get(fmt.Sprintf("/proposalById/%d", id))
 	if err = processConnectionError(res, err); err != nil {
 		return ProposalResult{}, err
 	}
@@ -117,3 +263,172 @@ func (api *API) Proposal(id int64) (ProposalResult, error) {
 	// process result
 	return respValue.Result, nil
 }
+
+func (api *API) restProposal(id int64) (ProposalResult, error) {
+	//request
+	res, err := api.client.rest.R().Get(fmt.Sprintf("/gov/proposals/%d", id))
+	if err = processConnectionError(res, err); err != nil {
+		return ProposalResult{}, err
+	}
+	//json decode
+	respValue := struct {
+		Result respDirectProposal `json:"result"`
+	}{}
+	err = universalJSONDecode(res.Body(), &respValue, nil, func() (bool, bool) {
+		return respValue.Result.ProposalID == id, false
+	})
+	if err != nil {
+		return ProposalResult{}, err
+	}
+	//process result
+	tally := normalizeTally(respValue.Result.FinalTallyResult)
+	// final tally is filled only when voting ends
+	if respValue.Result.Status == proposalStatusVotingPeriod {
+		if tally, err = api.restProposalTally(id); err != nil {
+			return ProposalResult{}, err
+		}
+	}
+	proposal := directResponse2Proposal(respValue.Result, tally)
+	votes, err := api.restProposalVotes(id)
+	if err != nil {
+		return ProposalResult{}, err
+	}
+	proposal.Votes.Count = len(votes)
+	proposal.Votes.Votes = votes
+	return proposal, nil
+}
+
+// proposalStatusVotingPeriod is a status of the proposal which is being voted in node responses.
+const proposalStatusVotingPeriod = "VotingPeriod"
+
+// ProposalVotes requests votes of validators for the proposal with specified id.
+// Gateway: ok, RPC/REST: partial (only validator and option are available)
+func (api *API) ProposalVotes(id int64) ([]ProposalVote, error) {
+	if api.directConn == nil {
+		proposal, err := api.apiProposal(id)
+		if err != nil {
+			return nil, err
+		}
+		return proposal.Votes.Votes, nil
+	} else {
+		return api.restProposalVotes(id)
+	}
+}
+
+func (api *API) restProposalVotes(id int64) ([]ProposalVote, error) {
+	//request
+	res, err := api.client.rest.R().Get(fmt.Sprintf("/gov/proposals/%d/votes", id))
+	if err = processConnectionError(res, err); err != nil {
+		return nil, err
+	}
+	//json decode
+	respValue := struct {
+		Result []respDirectVote `json:"result"`
+	}{}
+	err = universalJSONDecode(res.Body(), &respValue, nil, func() (bool, bool) {
+		return true, false
+	})
+	if err != nil {
+		return nil, err
+	}
+	//process result
+	votes := make([]ProposalVote, len(respValue.Result))
+	for i, v := range respValue.Result {
+		votes[i] = ProposalVote{ValidatorId: v.Voter, Vote: v.Option}
+	}
+	return votes, nil
+}
+
+// ProposalTally requests stakes of validators voted for the proposal with specified id.
+// It is current tally for the proposal being voted and final tally for finished one.
+// Gateway: ok, RPC/REST: ok
+func (api *API) ProposalTally(id int64) (ProposalTally, error) {
+	if api.directConn == nil {
+		proposal, err := api.apiProposal(id)
+		if err != nil {
+			return ProposalTally{}, err
+		}
+		return normalizeTally(ProposalTally{
+			Yes:     proposal.StakesYesPip,
+			Abstain: proposal.StakesAbstainPip,
+			No:      proposal.StakesNoPip,
+		}), nil
+	} else {
+		return api.restProposalTally(id)
+	}
+}
+
+func (api *API) restProposalTally(id int64) (ProposalTally, error) {
+	//request
+	res, err := api.client.rest.R().Get(fmt.Sprintf("/gov/proposals/%d/tally", id))
+	if err = processConnectionError(res, err); err != nil {
+		return ProposalTally{}, err
+	}
+	//json decode
+	respValue := struct {
+		Result ProposalTally `json:"result"`
+	}{}
+	err = universalJSONDecode(res.Body(), &respValue, nil, func() (bool, bool) {
+		return true, false
+	})
+	if err != nil {
+		return ProposalTally{}, err
+	}
+	//process result
+	return normalizeTally(respValue.Result), nil
+}
+
+// normalizeTally replaces missing stakes with zeros.
+func normalizeTally(t ProposalTally) ProposalTally {
+	for _, v := range []*sdk.Int{&t.Yes, &t.Abstain, &t.No} {
+		if v.BigInt() == nil {
+			*v = sdk.ZeroInt()
+		}
+	}
+	return t
+}
+
+// TallyParams contains parameters of proposal tallying.
+type TallyParams struct {
+	Quorum    sdk.Dec `json:"quorum"`    // Part of total stake of validators which must vote Yes to pass the proposal
+	Threshold sdk.Dec `json:"threshold"` // Part of Yes stake among Yes and No stakes to pass the proposal (used by the node before first update)
+}
+
+// DefaultTallyParams are tallying parameters of the network.
+var DefaultTallyParams = TallyParams{
+	Quorum:    sdk.NewDecWithPrec(667, 3),
+	Threshold: sdk.NewDecWithPrec(5, 1),
+}
+
+// GovTallyParams requests parameters of proposal tallying.
+// Gateway: partial (DefaultTallyParams are returned), RPC/REST: ok
+func (api *API) GovTallyParams() (TallyParams, error) {
+	if api.directConn == nil {
+		return DefaultTallyParams, nil
+	} else {
+		return api.restGovTallyParams()
+	}
+}
+
+func (api *API) restGovTallyParams() (TallyParams, error) {
+	//request
+	res, err := api.client.rest.R().Get("/gov/parameters/tallying")
+	if err = processConnectionError(res, err); err != nil {
+		return TallyParams{}, err
+	}
+	//json decode
+	respValue := struct {
+		Result TallyParams `json:"result"`
+	}{}
+	err = universalJSONDecode(res.Body(), &respValue, nil, func() (bool, bool) {
+		return true, false
+	})
+	if err != nil {
+		return TallyParams{}, err
+	}
+	//process result
+	if respValue.Result.Quorum.IsNil() || respValue.Result.Threshold.IsNil() {
+		return TallyParams{}, fmt.Errorf("invalid tallying parameters: %s", string(res.Body()))
+	}
+	return respValue.Result, nil
+}
@@ -0,0 +1,332 @@
+package api
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"bitbucket.org/decimalteam/decimal-go-sdk/wallet"
+)
+
+// TxDirection is a direction of transaction relative to the address.
+type TxDirection string
+
+// Transaction directions.
+const (
+	TxDirectionIncoming TxDirection = "incoming" // Address received coins
+	TxDirectionOutgoing TxDirection = "outgoing" // Address signed the transaction
+	TxDirectionSelf     TxDirection = "self"     // Address signed the transaction and received coins
+)
+
+// TxStatus is a status of transaction execution.
+type TxStatus string
+
+// Transaction statuses.
+const (
+	TxStatusSuccess TxStatus = "success"
+	TxStatusFailed  TxStatus = "failed"
+)
+
+// AddressTransaction contains decoded transaction related to the address.
+type AddressTransaction struct {
+	Hash           string      `json:"hash"`
+	Height         uint64      `json:"height"`
+	Timestamp      time.Time   `json:"timestamp"`
+	Direction      TxDirection `json:"direction"`
+	Messages       []string    `json:"messages"`       // Messages types, e.g. "coin/send_coin"
+	Counterparties []string    `json:"counterparties"` // Other addresses participating in transfers
+	Sent           sdk.Coins   `json:"sent"`           // Coins sent by the address
+	Received       sdk.Coins   `json:"received"`       // Coins received by the address
+	Gas            uint64      `json:"gas"`            // Gas wanted (amount of fee units)
+	FeeCoins       sdk.Coins   `json:"feeCoins"`       // Coins specified to pay the fee
+	Memo           string      `json:"memo"`
+	Status         TxStatus    `json:"status"`
+	Code           int64       `json:"code"`
+	Log            string      `json:"log"`
+	Tx             auth.StdTx  `json:"tx"` // Decoded transaction
+}
+
+// AddressTransactions requests transactions related to the specified address in the page specified by options.
+// Transactions are ordered by time starting from the newest one.
+// Gateway: ok, REST/RPC: ok
+func (api *API) AddressTransactions(address string, opts *ListOptions) ([]*AddressTransaction, error) {
+	var (
+		results []*TransactionResult
+		err     error
+	)
+	if opts == nil {
+		opts = &ListOptions{}
+	}
+	if api.directConn == nil {
+		results, err = api.apiAddressTransactions(address, opts)
+	} else {
+		results, err = api.rpcAddressTransactions(address, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	//process result
+	txs := make([]*AddressTransaction, 0, len(results))
+	heights := []uint64{}
+	seen := make(map[uint64]bool)
+	for _, res := range results {
+		tx, err := api.decodeAddressTransaction(address, res)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[tx.Height] {
+			seen[tx.Height] = true
+			heights = append(heights, tx.Height)
+		}
+		txs = append(txs, tx)
+	}
+	// times of blocks are requested once for each height
+	blockTimes := make([]time.Time, len(heights))
+	err = api.parallel(len(heights), func(i int) error {
+		var err error
+		blockTimes[i], err = api.blockTime(heights[i])
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	byHeight := make(map[uint64]time.Time, len(heights))
+	for i, height := range heights {
+		byHeight[height] = blockTimes[i]
+	}
+	for _, tx := range txs {
+		tx.Timestamp = byHeight[tx.Height]
+	}
+	return txs, nil
+}
+
+// apiAddressTransactions requests page of address transactions hashes from the gateway
+// and then requests transactions in parallel to decode them the same way as on direct connection.
+func (api *API) apiAddressTransactions(address string, opts *ListOptions) ([]*TransactionResult, error) {
+	type responseType struct {
+		OK     bool `json:"ok"`
+		Result struct {
+			Count uint64 `json:"count"`
+			Txs   []struct {
+				Hash string `json:"hash"`
+			} `json:"txs"`
+		} `json:"result"`
+	}
+	//request
+	res, err := api.client.rest.R().SetQueryParams(opts.gatewayParams()).Get(fmt.Sprintf("/address/%s/txs", address))
+	if err = processConnectionError(res, err); err != nil {
+		return nil, err
+	}
+	//json decode
+	respValue, respErr := responseType{}, Error{}
+	err = universalJSONDecode(res.Body(), &respValue, &respErr, func() (bool, bool) {
+		return respValue.OK, respErr.StatusCode != 0
+	})
+	if err != nil {
+		return nil, joinErrors(err, respErr)
+	}
+	//process result
+	hashes := respValue.Result.Txs
+	results := make([]*TransactionResult, len(hashes))
+	err = api.parallel(len(hashes), func(i int) error {
+		var err error
+		results[i], err = api.Transaction(hashes[i].Hash)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// rpcAddressTransactions searches transactions sent or received by the address and merges both lists.
+// Since both lists are ordered, it is enough to request first offset+limit transactions from each one.
+func (api *API) rpcAddressTransactions(address string, opts *ListOptions) ([]*TransactionResult, error) {
+	const perPage = 100
+	wanted := int(opts.offset() + opts.limit())
+	merged := make(map[string]*TransactionResult)
+	for _, query := range []string{
+		fmt.Sprintf("message.sender='%s'", address),
+		fmt.Sprintf("transfer.recipient='%s'", address),
+	} {
+		for page, count := 1, 0; count < wanted; page++ {
+			found, err := api.rpcTxSearch(query, page, perPage, "desc")
+			if err != nil {
+				return nil, err
+			}
+			for _, tx := range found.Txs {
+				merged[tx.Hash] = tx
+			}
+			count += len(found.Txs)
+			if len(found.Txs) == 0 || uint64(count) >= uint64(found.TotalCount) {
+				break
+			}
+		}
+	}
+	//order by time (height and index in the block) starting from the newest one
+	results := make([]*TransactionResult, 0, len(merged))
+	for _, tx := range merged {
+		results = append(results, tx)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		hi, _ := strconv.ParseUint(results[i].Height, 10, 64)
+		hj, _ := strconv.ParseUint(results[j].Height, 10, 64)
+		if hi == hj {
+			return results[i].Index > results[j].Index
+		}
+		return hi > hj
+	})
+	start, end, _ := opts.window(len(results))
+	return results[start:end], nil
+}
+
+// blockTimesCacheSize limits amount of block times remembered by the API instance.
+const blockTimesCacheSize = 4096
+
+// blockTime returns time of the block with specified height. Times of blocks do not change,
+// so requested times are remembered (the cache is cleared when it exceeds blockTimesCacheSize).
+func (api *API) blockTime(height uint64) (time.Time, error) {
+	api.mtx.RLock()
+	blockTime, ok := api.blockTimes[height]
+	api.mtx.RUnlock()
+	if ok {
+		return blockTime, nil
+	}
+	blockTime, err := api.requestBlockTime(height)
+	if err != nil {
+		return time.Time{}, err
+	}
+	api.mtx.Lock()
+	if api.blockTimes == nil || len(api.blockTimes) >= blockTimesCacheSize {
+		api.blockTimes = make(map[uint64]time.Time)
+	}
+	api.blockTimes[height] = blockTime
+	api.mtx.Unlock()
+	return blockTime, nil
+}
+
+// requestBlockTime requests time of the block with specified height.
+func (api *API) requestBlockTime(height uint64) (time.Time, error) {
+	type responseType struct {
+		Result struct {
+			Block struct {
+				Header struct {
+					Time time.Time `json:"time"`
+				} `json:"header"`
+			} `json:"block"`
+		} `json:"result"`
+	}
+	url := "/block"
+	if api.directConn == nil {
+		url = "/rpc/block"
+	}
+	//request
+	res, err := api.client.rpc.R().SetQueryParam("height", strconv.FormatUint(height, 10)).Get(url)
+	if err = processConnectionError(res, err); err != nil {
+		return time.Time{}, err
+	}
+	//json decode
+	respValue, respErr := responseType{}, JsonRPCError{}
+	err = universalJSONDecode(res.Body(), &respValue, &respErr, func() (bool, bool) {
+		return !respValue.Result.Block.Header.Time.IsZero(), respErr.InternalError.Code != 0
+	})
+	if err != nil {
+		return time.Time{}, joinErrors(err, respErr)
+	}
+	return respValue.Result.Block.Header.Time, nil
+}
+
+// decodeAddressTransaction decodes raw transaction and describes it relative to the address.
+func (api *API) decodeAddressTransaction(address string, res *TransactionResult) (*AddressTransaction, error) {
+	txBytes, err := base64.StdEncoding.DecodeString(res.Tx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode transaction %s: %w", res.Hash, err)
+	}
+	stdTx := auth.StdTx{}
+	if err = api.codec.UnmarshalBinaryLengthPrefixed(txBytes, &stdTx); err != nil {
+		return nil, fmt.Errorf("unable to decode transaction %s: %w", res.Hash, err)
+	}
+	height, _ := strconv.ParseUint(res.Height, 10, 64)
+	tx := &AddressTransaction{
+		Hash:     res.Hash,
+		Height:   height,
+		Gas:      stdTx.Fee.Gas,
+		FeeCoins: stdTx.Fee.Amount,
+		Memo:     stdTx.Memo,
+		Status:   TxStatusSuccess,
+		Tx:       stdTx,
+		Sent:     sdk.NewCoins(),
+		Received: sdk.NewCoins(),
+	}
+	if res.TxResult != nil {
+		tx.Code = res.TxResult.Code
+		tx.Log = res.TxResult.Log
+		if res.TxResult.Code != 0 {
+			tx.Status = TxStatusFailed
+		}
+	}
+
+	// Collect transfers from messages
+	signed := false
+	counterparties := make(map[string]bool)
+	transfer := func(from, to string, coin sdk.Coin) {
+		switch address {
+		case from:
+			tx.Sent = tx.Sent.Add(coin)
+			counterparties[to] = true
+		case to:
+			tx.Received = tx.Received.Add(coin)
+			counterparties[from] = true
+		}
+	}
+	for _, msg := range stdTx.Msgs {
+		tx.Messages = append(tx.Messages, fmt.Sprintf("%s/%s", msg.Route(), msg.Type()))
+		for _, signer := range msg.GetSigners() {
+			if signer.String() == address {
+				signed = true
+			}
+		}
+		switch m := msg.(type) {
+		case MsgSendCoin:
+			transfer(m.Sender.String(), m.Receiver.String(), m.Coin)
+		case MsgMultiSendCoin:
+			for _, send := range m.Sends {
+				transfer(m.Sender.String(), send.Receiver.String(), send.Coin)
+			}
+		case MsgRedeemCheck:
+			check, err := wallet.ParseCheck(base58.Decode(m.Check))
+			if err != nil {
+				continue
+			}
+			issuer, err := check.Sender()
+			if err != nil {
+				continue
+			}
+			coin := sdk.NewCoin(strings.ToLower(check.Coin), sdk.NewIntFromBigInt(check.Amount))
+			transfer(issuer.String(), m.Sender.String(), coin)
+		}
+	}
+	delete(counterparties, address)
+	for counterparty := range counterparties {
+		tx.Counterparties = append(tx.Counterparties, counterparty)
+	}
+	sort.Strings(tx.Counterparties)
+
+	switch {
+	case signed && !tx.Received.IsZero():
+		tx.Direction = TxDirectionSelf
+	case signed:
+		tx.Direction = TxDirectionOutgoing
+	default:
+		tx.Direction = TxDirectionIncoming
+	}
+	return tx, nil
+}
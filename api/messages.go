@@ -1,6 +1,11 @@
 package api
 
 import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
 	"bitbucket.org/decimalteam/go-node/x/coin"
 	"bitbucket.org/decimalteam/go-node/x/gov"
 	"bitbucket.org/decimalteam/go-node/x/multisig"
@@ -21,6 +26,8 @@ type (
 	MsgSendCoin = coin.MsgSendCoin
 	// MsgMultiSendCoin .
 	MsgMultiSendCoin = coin.MsgMultiSendCoin
+	// Send is a single send of MsgMultiSendCoin
+	Send = coin.Send
 	// MsgBuyCoin .
 	MsgBuyCoin = coin.MsgBuyCoin
 	// MsgUpdateCoint
@@ -93,8 +100,35 @@ type (
 	MsgSubmitProposal = gov.MsgSubmitProposal
 	// MsgVote .
 	MsgVote = gov.MsgVote
+	// VoteOption .
+	VoteOption = gov.VoteOption
 )
 
+// Vote options.
+const (
+	VoteOptionYes     VoteOption = 0x01
+	VoteOptionAbstain VoteOption = 0x02
+	VoteOptionNo      VoteOption = 0x03
+)
+
+// NewMsgVote creates MsgVote message
+func NewMsgVote(voter sdk.ValAddress, proposalID uint64, option VoteOption) MsgVote {
+	return MsgVote{ProposalID: proposalID, Voter: voter, Option: option}
+}
+
+// ParseVoteOption parses vote option from string ("yes", "no" or "abstain", case insensitive).
+func ParseVoteOption(option string) (VoteOption, error) {
+	switch strings.ToLower(option) {
+	case "yes":
+		return VoteOptionYes, nil
+	case "abstain":
+		return VoteOptionAbstain, nil
+	case "no":
+		return VoteOptionNo, nil
+	}
+	return 0, fmt.Errorf("invalid vote option %q", option)
+}
+
 ////////////////////////////////////////////////////////////////
 // Module: validator
 ////////////////////////////////////////////////////////////////
@@ -117,6 +151,8 @@ type (
 	MsgDelegateNFT = validator.MsgDelegateNFT
 	// MsgUnbondNFT
 	MsgUnbondNFT = validator.MsgUnbondNFT
+	// Description of the validator specified by its operator.
+	Description = validator.Description
 )
 
 // Initializing functions.
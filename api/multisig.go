@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"sort"
 	"time"
 )
 
@@ -96,14 +97,21 @@ type BalanceEntry struct {
 	Avatar string `json:"avatar"`
 }
 
-// TODO: undefined interfaces with miltisigs in REST/RPC.
-// https://bitbucket.org/decimalteam/go-node/src/master/x/multisig/client/rest/query.go
+// Node has no REST routes for multisig wallets, so on direct connection
+// multisig module querier is requested using Tendermint RPC `abci_query` method.
+// https://bitbucket.org/decimalteam/go-node/src/master/x/multisig/internal/keeper/querier.go
 
 // MultisigWallets requests full list of multisig wallets which has account with specified address as participant.
+// Gateway: ok, REST/RPC: partial (no creation/update time and creator)
 func (api *API) MultisigWallets(address string) ([]*MultisigWalletsResult, error) {
-	if api.directConn != nil {
-		return nil, ErrNotImplemented
+	if api.directConn == nil {
+		return api.apiMultisigWallets(address)
+	} else {
+		return api.rpcMultisigWallets(address)
 	}
+}
+
+func (api *API) apiMultisigWallets(address string) ([]*MultisigWalletsResult, error) {
 	//request
 	res, err := api.client.rest.R().Get(fmt.Sprintf("/address/%s/multisigs", address))
 	if err = processConnectionError(res, err); err != nil {
@@ -122,10 +130,16 @@ func (api *API) MultisigWallets(address string) ([]*MultisigWalletsResult, error
 }
 
 // MultisigWallet requests multisig wallet with specified address.
+// Gateway: ok, REST/RPC: partial (no creation/update time and creator, no signing time of transactions)
 func (api *API) MultisigWallet(address string) (*MultisigWalletResult, error) {
-	if api.directConn != nil {
-		return nil, ErrNotImplemented
+	if api.directConn == nil {
+		return api.apiMultisigWallet(address)
+	} else {
+		return api.rpcMultisigWallet(address)
 	}
+}
+
+func (api *API) apiMultisigWallet(address string) (*MultisigWalletResult, error) {
 	//request
 	res, err := api.client.rest.R().Get(fmt.Sprintf("/multisig/%s", address))
 	if err = processConnectionError(res, err); err != nil {
@@ -144,14 +158,32 @@ func (api *API) MultisigWallet(address string) (*MultisigWalletResult, error) {
 }
 
 // MultisigTransactions requests full list of transactions in multisig wallet with specified address.
+// Gateway: ok, REST/RPC: partial (no signing time, update time is the same as creation time)
 func (api *API) MultisigTransactions(address string) ([]*MultisigTransactionResult, error) {
-	if api.directConn != nil {
-		return nil, ErrNotImplemented
+	txs, _, err := api.multisigTransactionsPage(address, nil)
+	return txs, err
+}
+
+// MultisigTransactionsWithOptions requests transactions in multisig wallet with specified address
+// in the page specified by options.
+func (api *API) MultisigTransactionsWithOptions(address string, opts *ListOptions) ([]*MultisigTransactionResult, error) {
+	txs, _, err := api.multisigTransactionsPage(address, opts)
+	return txs, err
+}
+
+func (api *API) multisigTransactionsPage(address string, opts *ListOptions) ([]*MultisigTransactionResult, bool, error) {
+	if api.directConn == nil {
+		return api.apiMultisigTransactionsPage(address, opts)
+	} else {
+		return api.rpcMultisigTransactionsPage(address, opts)
 	}
+}
+
+func (api *API) apiMultisigTransactionsPage(address string, opts *ListOptions) ([]*MultisigTransactionResult, bool, error) {
 	//request
-	res, err := api.client.rest.R().Get(fmt.Sprintf("/multisig/%s/txs", address))
+	res, err := api.client.rest.R().SetQueryParams(opts.gatewayParams()).Get(fmt.Sprintf("/multisig/%s/txs", address))
 	if err = processConnectionError(res, err); err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	//json decode
 	respValue, respErr := MultisigTransactionsResponse{}, Error{}
@@ -159,8 +191,194 @@ func (api *API) MultisigTransactions(address string) ([]*MultisigTransactionResu
 		return respValue.OK, respErr.StatusCode != 0
 	})
 	if err != nil {
-		return nil, joinErrors(err, respErr)
+		return nil, false, joinErrors(err, respErr)
 	}
 	//process result
-	return respValue.Result.Transactions, nil
+	hasMore := opts.offset()+uint64(len(respValue.Result.Transactions)) < uint64(respValue.Result.Count)
+	return respValue.Result.Transactions, hasMore, nil
+}
+
+////////////////////////////////////////////////////////////////
+// Direct connection (node querier)
+////////////////////////////////////////////////////////////////
+
+// nodeMultisigWallet contains multisig wallet as returned by the node querier.
+type nodeMultisigWallet struct {
+	Address   string     `json:"address"`
+	Owners    []string   `json:"owners"`
+	Weights   []jsonUint `json:"weights"`
+	Threshold jsonUint   `json:"threshold"`
+}
+
+// nodeMultisigTransaction contains multisig transaction as returned by the node querier.
+type nodeMultisigTransaction struct {
+	ID       string `json:"id"`
+	Wallet   string `json:"wallet"`
+	Receiver string `json:"receiver"`
+	Coins    []struct {
+		Denom  string `json:"denom"`
+		Amount string `json:"amount"`
+	} `json:"coins"`
+	Signers   []string `json:"signers"`    // Signers in order of wallet owners, empty if owner has not signed yet
+	CreatedAt jsonUint `json:"created_at"` // Block height
+}
+
+func (w *nodeMultisigWallet) toMultisigWallet() *MultisigWallet {
+	return &MultisigWallet{
+		Address:   w.Address,
+		Threshold: uint64(w.Threshold),
+	}
+}
+
+// weightOf returns weight of the wallet owner with specified address.
+func (w *nodeMultisigWallet) weightOf(owner string) uint64 {
+	for i, o := range w.Owners {
+		if o == owner && i < len(w.Weights) {
+			return uint64(w.Weights[i])
+		}
+	}
+	return 0
+}
+
+func (api *API) rpcMultisigWallets(address string) ([]*MultisigWalletsResult, error) {
+	//request
+	wallets := []*nodeMultisigWallet{}
+	err := api.rpcABCIQuery(fmt.Sprintf("custom/multisig/listWallets/%s", address), &wallets)
+	if err != nil {
+		return nil, err
+	}
+	//process result
+	result := make([]*MultisigWalletsResult, 0, len(wallets))
+	for _, w := range wallets {
+		result = append(result, &MultisigWalletsResult{
+			MultisigWalletOwner: MultisigWalletOwner{
+				Address:  address,
+				Multisig: w.Address,
+				Weight:   w.weightOf(address),
+			},
+			Wallet: w.toMultisigWallet(),
+		})
+	}
+	return result, nil
+}
+
+// rpcMultisigWalletInfo requests multisig wallet with specified address from the node querier.
+func (api *API) rpcMultisigWalletInfo(address string) (*nodeMultisigWallet, error) {
+	wallet := nodeMultisigWallet{}
+	err := api.rpcABCIQuery(fmt.Sprintf("custom/multisig/getWallet/%s", address), &wallet)
+	if err != nil {
+		return nil, err
+	}
+	if wallet.Address == "" {
+		return nil, fmt.Errorf("multisig wallet %s not found", address)
+	}
+	return &wallet, nil
+}
+
+func (api *API) rpcMultisigWallet(address string) (*MultisigWalletResult, error) {
+	//request
+	wallet, err := api.rpcMultisigWalletInfo(address)
+	if err != nil {
+		return nil, err
+	}
+	txs, err := api.rpcMultisigWalletTransactions(wallet)
+	if err != nil {
+		return nil, err
+	}
+	account, err := api.restAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	//process result
+	owners := make([]*MultisigWalletOwner, 0, len(wallet.Owners))
+	for _, owner := range wallet.Owners {
+		owners = append(owners, &MultisigWalletOwner{
+			Address:  owner,
+			Multisig: wallet.Address,
+			Weight:   wallet.weightOf(owner),
+		})
+	}
+	balance := make(map[string]BalanceEntry, len(account.Balance))
+	for symbol, value := range account.Balance {
+		balance[symbol] = BalanceEntry{Value: value}
+	}
+	return &MultisigWalletResult{
+		MultisigWallet: *wallet.toMultisigWallet(),
+		Owners:         owners,
+		Transactions:   txs,
+		Account: &MultisigAccount{
+			ID:      account.ID,
+			Address: address,
+			Balance: balance,
+		},
+	}, nil
+}
+
+func (api *API) rpcMultisigTransactionsPage(address string, opts *ListOptions) ([]*MultisigTransactionResult, bool, error) {
+	//request
+	wallet, err := api.rpcMultisigWalletInfo(address)
+	if err != nil {
+		return nil, false, err
+	}
+	txs, err := api.rpcMultisigWalletTransactions(wallet)
+	if err != nil {
+		return nil, false, err
+	}
+	//process result
+	start, end, hasMore := opts.window(len(txs))
+	return txs[start:end], hasMore, nil
+}
+
+// rpcMultisigWalletTransactions requests all transactions of the multisig wallet from the node querier
+// and calculates confirmations using weights of the wallet owners.
+func (api *API) rpcMultisigWalletTransactions(wallet *nodeMultisigWallet) ([]*MultisigTransactionResult, error) {
+	//request
+	txs := []*nodeMultisigTransaction{}
+	err := api.rpcABCIQuery(fmt.Sprintf("custom/multisig/listTransactions/%s", wallet.Address), &txs)
+	if err != nil {
+		return nil, err
+	}
+	//process result
+	blockTimes := make(map[uint64]time.Time)
+	result := make([]*MultisigTransactionResult, 0, len(txs))
+	for _, tx := range txs {
+		height := uint64(tx.CreatedAt)
+		if _, ok := blockTimes[height]; !ok {
+			blockTimes[height], err = api.blockTime(height)
+			if err != nil {
+				return nil, err
+			}
+		}
+		r := &MultisigTransactionResult{
+			Transaction: tx.ID,
+			Address:     tx.Wallet,
+			To:          tx.Receiver,
+			CreatedAt:   blockTimes[height],
+			UpdatedAt:   blockTimes[height],
+		}
+		r.Data = make(map[string]struct {
+			SignerWeight uint64    `json:"signer_weight"`
+			Timestamp    time.Time `json:"timestamp"`
+		})
+		for _, signer := range tx.Signers {
+			if signer == "" {
+				continue
+			}
+			data := r.Data[signer]
+			data.SignerWeight = wallet.weightOf(signer)
+			r.Data[signer] = data
+			r.Confirmations += data.SignerWeight
+		}
+		r.Confirmed = r.Confirmations >= uint64(wallet.Threshold)
+		for _, coin := range tx.Coins {
+			r.Coins = append(r.Coins, &struct {
+				Coin   string `json:"coin"`
+				Amount string `json:"amount"`
+			}{Coin: coin.Denom, Amount: coin.Amount})
+		}
+		result = append(result, r)
+	}
+	// newest transactions first as on the gateway
+	sort.SliceStable(result, func(i, j int) bool { return result[i].CreatedAt.After(result[j].CreatedAt) })
+	return result, nil
 }
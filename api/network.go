@@ -0,0 +1,185 @@
+package api
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+
+	"bitbucket.org/decimalteam/go-node/config"
+)
+
+// Bech32Prefixes contains bech32 prefixes of addresses and public keys used in the network.
+type Bech32Prefixes struct {
+	AccAddr  string
+	AccPub   string
+	ValAddr  string
+	ValPub   string
+	ConsAddr string
+	ConsPub  string
+}
+
+// DecimalBech32Prefixes are bech32 prefixes used in all Decimal networks.
+var DecimalBech32Prefixes = Bech32Prefixes{
+	AccAddr:  config.DecimalPrefixAccAddr,
+	AccPub:   config.DecimalPrefixAccPub,
+	ValAddr:  config.DecimalPrefixValAddr,
+	ValPub:   config.DecimalPrefixValPub,
+	ConsAddr: config.DecimalPrefixConsAddr,
+	ConsPub:  config.DecimalPrefixConsPub,
+}
+
+// Network contains parameters of the network the API instance is connected to.
+type Network struct {
+	Name string
+
+	// URL of the gateway or host of the node (if DirectConn is not nil)
+	URL        string
+	DirectConn *DirectConn
+
+	// ChainID and BaseCoin are requested from the gateway or node if not specified.
+	// Clear ChainID of predefined profile to request it if the network was restarted with new chain ID.
+	ChainID  string
+	BaseCoin string
+
+	Bech32 Bech32Prefixes
+}
+
+// Predefined network profiles. Chain IDs are taken from genesis of the networks shipped with the node.
+var (
+	MainNet = Network{
+		Name:     "mainnet",
+		URL:      "https://mainnet-gate.decimalchain.com/api",
+		ChainID:  "decimal-mainnet-08-01",
+		BaseCoin: config.SymbolBaseCoin,
+		Bech32:   DecimalBech32Prefixes,
+	}
+	TestNet = Network{
+		Name:     "testnet",
+		URL:      "https://testnet-gate.decimalchain.com/api",
+		ChainID:  "decimal-testnet-29-05-02-00",
+		BaseCoin: config.SymbolTestBaseCoin,
+		Bech32:   DecimalBech32Prefixes,
+	}
+	DevNet = Network{
+		Name:     "devnet",
+		URL:      "https://devnet-gate.decimalchain.com/api",
+		ChainID:  "decimal-devnet-05-28-19-00",
+		BaseCoin: config.SymbolBaseCoin,
+		Bech32:   DecimalBech32Prefixes,
+	}
+)
+
+// Networks contains predefined network profiles by name.
+var Networks = map[string]Network{
+	MainNet.Name: MainNet,
+	TestNet.Name: TestNet,
+	DevNet.Name:  DevNet,
+}
+
+// WithDirectConn returns copy of the network profile using direct connection to the node at specified host.
+func (n Network) WithDirectConn(hostURL string, directConn *DirectConn) Network {
+	if directConn == nil {
+		directConn = &DirectConn{}
+	}
+	n.URL = hostURL
+	n.DirectConn = directConn
+	return n
+}
+
+// NewAPIForNetwork creates Decimal API instance connected to the network.
+// It fails if Cosmos SDK config was set up before with other bech32 prefixes, see SetupConfig.
+func NewAPIForNetwork(network Network) (*API, error) {
+	return NewAPIForNetworkWithClient(
+		network,
+		resty.New().SetTimeout(time.Minute),
+		resty.New().SetTimeout(time.Minute),
+	)
+}
+
+// NewAPIForNetworkWithClient creates Decimal API instance connected to the network with custom Resty client.
+func NewAPIForNetworkWithClient(network Network, restClient *resty.Client, rpcClient *resty.Client) (*API, error) {
+	if network.Bech32 == (Bech32Prefixes{}) {
+		network.Bech32 = DecimalBech32Prefixes
+	}
+	api := newAPI(network, restClient, rpcClient)
+	if api.configErr != nil {
+		return nil, api.configErr
+	}
+	return api, nil
+}
+
+// Network returns parameters of the network the API instance is connected to.
+func (api *API) Network() Network {
+	api.mtx.RLock()
+	defer api.mtx.RUnlock()
+	return api.network
+}
+
+// BaseCoin returns symbol of base coin in the network.
+// If it is not specified in the network profile, it is requested from the node genesis
+// (direct connection only) or derived from the chain ID.
+// Gateway: ok, REST/RPC: ok
+func (api *API) BaseCoin() (string, error) {
+	api.mtx.RLock()
+	baseCoin, chainID := api.network.BaseCoin, api.chainID
+	api.mtx.RUnlock()
+	if baseCoin != "" {
+		return baseCoin, nil
+	}
+	if api.directConn != nil {
+		if symbol, err := api.rpcGenesisBaseCoin(); err == nil && symbol != "" {
+			api.setBaseCoin(symbol)
+			return symbol, nil
+		}
+	}
+	if chainID == "" {
+		var err error
+		if chainID, err = api.ChainID(); err != nil {
+			return "", err
+		}
+	}
+	baseCoin = config.GetDefaultConfig(chainID).SymbolBaseCoin
+	api.setBaseCoin(baseCoin)
+	return baseCoin, nil
+}
+
+func (api *API) setBaseCoin(symbol string) {
+	api.mtx.Lock()
+	defer api.mtx.Unlock()
+	api.network.BaseCoin = symbol
+}
+
+func (api *API) rpcGenesisBaseCoin() (string, error) {
+	type responseType struct {
+		Result struct {
+			Genesis struct {
+				AppState struct {
+					Coin json.RawMessage `json:"coin"`
+				} `json:"app_state"`
+			} `json:"genesis"`
+		} `json:"result"`
+	}
+	type coinGenesis struct {
+		Symbol string `json:"symbol"`
+	}
+	//request
+	res, err := api.client.rpc.R().Get("/genesis")
+	if err = processConnectionError(res, err); err != nil {
+		return "", err
+	}
+	//json decode
+	respValue, respErr := responseType{}, JsonRPCError{}
+	err = universalJSONDecode(res.Body(), &respValue, &respErr, func() (bool, bool) {
+		return len(respValue.Result.Genesis.AppState.Coin) > 0, respErr.InternalError.Code != 0
+	})
+	if err != nil {
+		return "", joinErrors(err, respErr)
+	}
+	//process result
+	coin := coinGenesis{}
+	if err = json.Unmarshal(respValue.Result.Genesis.AppState.Coin, &coin); err != nil {
+		return "", err
+	}
+	return coin.Symbol, nil
+}
@@ -1,37 +1,40 @@
 package api
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // Full NTF info
 // TODO: replace replace small integers, serialized as string, to int64
 type NFT struct {
-	Id             string     `json:"nftId"`
-	CollectionName string     `json:"nftCollection"`
-	Quantity       string     `json:"quantity"`
-	StartReserve   string     `json:"startReserve"`
-	TotalReserve   string     `json:"totalReserve"`
-	TokenURI       string     `json:"tokenUri"`
-	AllowMint      bool       `json:"allowMint"`
-	NonFungible    bool       `json:"nonFungible"`
-	TxHash         string     `json:"txHash"`
-	BlockId        int64      `json:"blockId"`
-	CreatedAt      string     `json:"createdAt"`
-	UpdatedAt      string     `json:"updatedAt"`
-	Slug           string     `json:"slug"`
-	Headline       string     `json:"headline"`
-	Description    string     `json:"description"`
-	NFTOwner       []NFTOwner `json:"nftOwner"`
-	NFTReserve     []struct {
-		SubTokenId  string `json:"subTokenId"`
-		Reserve     string `json:"reserve"`
-		Address     string `json:"address"`
-		Delegated   bool   `json:"delegated"`
-		ValidatorId string `json:"validatorId"`
-	} `json:"nftReserve"`
-	Misc struct {
+	Id             string       `json:"nftId"`
+	CollectionName string       `json:"nftCollection"`
+	Creator        string       `json:"creator"`
+	Quantity       string       `json:"quantity"`
+	StartReserve   string       `json:"startReserve"`
+	TotalReserve   string       `json:"totalReserve"`
+	TokenURI       string       `json:"tokenUri"`
+	AllowMint      bool         `json:"allowMint"`
+	NonFungible    bool         `json:"nonFungible"`
+	TxHash         string       `json:"txHash"`
+	BlockId        int64        `json:"blockId"`
+	CreatedAt      string       `json:"createdAt"`
+	UpdatedAt      string       `json:"updatedAt"`
+	Slug           string       `json:"slug"`
+	Headline       string       `json:"headline"`
+	Description    string       `json:"description"`
+	NFTOwner       []NFTOwner   `json:"nftOwner"`
+	NFTReserve     []NFTReserve `json:"nftReserve"`
+	Misc           struct {
 		CoverHash      string `json:"coverHash"`
 		CoverPath      string `json:"coverPath"`
 		CoverExtension string `json:"coverExtension"`
@@ -42,8 +45,37 @@ type NFT struct {
 }
 
 type NFTOwner struct {
-	Address  string `json:"address"`
-	Quantity string `json:"quantity"`
+	Address     string  `json:"address"`
+	Quantity    string  `json:"quantity"`
+	SubTokenIDs []int64 `json:"subTokenIds"` // Sub-tokens held by the owner (direct connection only)
+}
+
+// NFTReserve contains reserve of NFT sub-token and its holder.
+type NFTReserve struct {
+	SubTokenId  string `json:"subTokenId"`
+	Reserve     string `json:"reserve"`
+	Address     string `json:"address"` // Owner or delegator of the sub-token
+	Delegated   bool   `json:"delegated"`
+	ValidatorId string `json:"validatorId"` // Validator the sub-token is delegated to
+	Unbonded    bool   `json:"unbonded"`    // Sub-token is being unbonded from the validator
+}
+
+// NFTSubToken contains reserve of NFT sub-token.
+type NFTSubToken struct {
+	ID      int64   `json:"id"`
+	Reserve sdk.Int `json:"reserve"` // Reserve in pip of base coin
+}
+
+// NFTStake contains sub-tokens of NFT delegated to the validator.
+type NFTStake struct {
+	Validator      string    `json:"validator"` // Address with prefix "dxvaloper"
+	Delegator      string    `json:"delegator"`
+	Collection     string    `json:"collection"`
+	NftId          string    `json:"nftId"`
+	SubTokenIDs    []int64   `json:"subTokenIds"`
+	Reserve        sdk.Int   `json:"reserve"` // Total reserve of sub-tokens in pip (stake in base coin)
+	Unbonding      bool      `json:"unbonding"`
+	CompletionTime time.Time `json:"completionTime"` // Time of unbonding completion (direct connection only)
 }
 
 type NFTShort struct {
@@ -58,14 +90,25 @@ type NFTShort struct {
 
 // Get all NFT in short format
 func (api *API) NFTList() ([]*NFTShort, error) {
+	nfts, _, err := api.nftListPage(nil)
+	return nfts, err
+}
+
+// Get NFT in short format in the page specified by options
+func (api *API) NFTListWithOptions(opts *ListOptions) ([]*NFTShort, error) {
+	nfts, _, err := api.nftListPage(opts)
+	return nfts, err
+}
+
+func (api *API) nftListPage(opts *ListOptions) ([]*NFTShort, bool, error) {
 	if api.directConn == nil {
-		return api.apiNFTList()
+		return api.apiNFTList(opts)
 	} else {
-		return api.restNFTList()
+		return api.restNFTList(opts)
 	}
 }
 
-func (api *API) apiNFTList() ([]*NFTShort, error) {
+func (api *API) apiNFTList(opts *ListOptions) ([]*NFTShort, bool, error) {
 	var result []*NFTShort
 	type responseType struct {
 		OK     bool `json:"ok"`
@@ -80,9 +123,9 @@ func (api *API) apiNFTList() ([]*NFTShort, error) {
 		} `json:"result"`
 	}
 	//request
-	res, err := api.client.rest.R().Get("/nfts")
+	res, err := api.client.rest.R().SetQueryParams(opts.gatewayParams()).Get("/nfts")
 	if err = processConnectionError(res, err); err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	//json decode
 	respValue, respErr := responseType{}, Error{}
@@ -90,22 +133,22 @@ func (api *API) apiNFTList() ([]*NFTShort, error) {
 		return respValue.OK, respErr.StatusCode != 0
 	})
 	if err != nil {
-		return nil, joinErrors(err, respErr)
+		return nil, false, joinErrors(err, respErr)
 	}
 	//process
 	err = nil
 	for _, rec := range respValue.Result {
 		q, err := strconv.ParseInt(rec.RawQuantity, 10, 64)
 		if err != nil {
-			return []*NFTShort{}, fmt.Errorf("Cannot convert Quantity '%s' to int", rec.RawQuantity)
+			return []*NFTShort{}, false, fmt.Errorf("Cannot convert Quantity '%s' to int", rec.RawQuantity)
 		}
 		d, err := strconv.ParseInt(rec.RawDelegated, 10, 64)
 		if err != nil {
-			return []*NFTShort{}, fmt.Errorf("Cannot convert Delegated '%s' to int", rec.RawDelegated)
+			return []*NFTShort{}, false, fmt.Errorf("Cannot convert Delegated '%s' to int", rec.RawDelegated)
 		}
 		u, err := strconv.ParseInt(rec.RawUnbound, 10, 64)
 		if err != nil {
-			return []*NFTShort{}, fmt.Errorf("Cannot convert Unbound '%s' to int", rec.RawUnbound)
+			return []*NFTShort{}, false, fmt.Errorf("Cannot convert Unbound '%s' to int", rec.RawUnbound)
 		}
 		result = append(result, &NFTShort{
 			Id:             rec.RawId,
@@ -117,104 +160,452 @@ func (api *API) apiNFTList() ([]*NFTShort, error) {
 			Unbound:        u,
 		})
 	}
-	return result, nil
+	// gateway does not return total count, so full page means there may be more items
+	hasMore := opts != nil && uint64(len(respValue.Result)) == opts.limit()
+	return result, hasMore, nil
 }
 
-func (api *API) restNFTList() ([]*NFTShort, error) {
-	var result []*NFTShort
-	type responseDenomsType struct {
-		Result []string `json:"result"`
+// Get NFT owned by account
+func (api *API) NFTByAddress(address string) ([]*NFT, error) {
+	if api.directConn == nil {
+		return api.apiNFTByAddress(address)
+	} else {
+		return api.restNFTByAddress(address)
+	}
+}
+
+func (api *API) apiNFTByAddress(address string) ([]*NFT, error) {
+	type responseType struct {
+		OK     bool `json:"ok"`
+		Result struct {
+			Tokens []*NFT `json:"tokens"`
+		}
+	}
+	//request
+	res, err := api.client.rest.R().Get(fmt.Sprintf("/address/%s/nfts", address))
+	if err = processConnectionError(res, err); err != nil {
+		return []*NFT{}, err
 	}
+	//json decode
+	respValue, respErr := responseType{}, Error{}
+	err = universalJSONDecode(res.Body(), &respValue, &respErr, func() (bool, bool) {
+		return respValue.OK, respErr.StatusCode != 0
+	})
+	if err != nil {
+		return nil, joinErrors(err, respErr)
+	}
+	//process result
+	return respValue.Result.Tokens, nil
+}
+
+// NFT requests full information about NFT with specified id.
+// Direct connection looks for the NFT in all collections, use NFTByCollection if the collection is known.
+// Gateway: ok, REST/RPC: ok (creation and gateway specific fields are not available)
+func (api *API) NFT(id string) (*NFT, error) {
+	if api.directConn == nil {
+		return api.apiNFT(id)
+	} else {
+		return api.restNFT(id)
+	}
+}
+
+func (api *API) apiNFT(id string) (*NFT, error) {
 	type responseNFTType struct {
-		Result map[string]struct {
-			NFTs map[string]struct {
-				RawId      string `json:"id"`
-				RawCreator string `json:"creator"`
-			} `json:"nfts"`
-		} `json:"result"`
+		OK     bool `json:"ok"`
+		Result *NFT `json:"result"`
 	}
-	// 1 get collection list
 	//request
-	res, err := api.client.rest.R().Get("/nft/denoms")
+	res, err := api.client.rest.R().Get(fmt.Sprintf("/nfts/%s", id))
 	if err = processConnectionError(res, err); err != nil {
-		return []*NFTShort{}, err
+		return nil, err
 	}
 	//json decode
-	response := responseDenomsType{}
-	err = universalJSONDecode(res.Body(), &response, nil, func() (bool, bool) {
-		return len(response.Result) > 0, false
+	respValue, respErr := responseNFTType{}, Error{}
+	err = universalJSONDecode(res.Body(), &respValue, &respErr, func() (bool, bool) {
+		return respValue.OK, respErr.StatusCode != 0
 	})
 	if err != nil {
-		return []*NFTShort{}, err
+		return nil, joinErrors(err, respErr)
 	}
-	// 2 get nfts from collections
-	for _, denom := range response.Result {
-		//request
-		res, err := api.client.rest.R().Get(fmt.Sprintf("/nft/collection/%s", denom))
-		if err = processConnectionError(res, err); err != nil {
-			return []*NFTShort{}, err
-		}
-		//json decode
-		respValue := responseNFTType{}
-		err = universalJSONDecode(res.Body(), &respValue, nil, func() (bool, bool) {
-			return len(respValue.Result) > 0, false
-		})
+	//process result
+	return respValue.Result, nil
+}
+
+// NFTByCollection requests full information about NFT with specified id in the collection.
+// Gateway: ok, REST/RPC: ok (creation and gateway specific fields are not available)
+func (api *API) NFTByCollection(denom, id string) (*NFT, error) {
+	if api.directConn == nil {
+		token, err := api.apiNFT(id)
 		if err != nil {
-			return []*NFTShort{}, err
-		}
-		//process
-		for _, v1 := range respValue.Result {
-			for _, v2 := range v1.NFTs {
-				result = append(result, &NFTShort{
-					Id:             v2.RawId,
-					CollectionName: denom,
-					Creator:        v2.RawCreator,
-					CreatedAt:      "",
-					Quantity:       0, //TODO
-					Delegated:      0, //TODO
-					Unbound:        0, //TODO
-				})
-			}
+			return nil, err
 		}
+		if token == nil || token.CollectionName != denom {
+			return nil, fmt.Errorf("NFT %s is %w in collection %s", id, ErrNotFound, denom)
+		}
+		return token, nil
+	} else {
+		return api.restNFTByCollection(denom, id)
 	}
+}
 
-	return result, nil
+// NFTSubTokens requests reserves of sub-tokens of the NFT with specified ids. Unknown sub-tokens are skipped.
+// Gateway: ok, REST/RPC: ok
+func (api *API) NFTSubTokens(denom, id string, subTokenIDs []int64) ([]NFTSubToken, error) {
+	if api.directConn == nil {
+		token, err := api.NFTByCollection(denom, id)
+		if err != nil {
+			return nil, err
+		}
+		reserves := make(map[int64]sdk.Int, len(token.NFTReserve))
+		for _, r := range token.NFTReserve {
+			subTokenID, err := strconv.ParseInt(r.SubTokenId, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid sub-token id %q", r.SubTokenId)
+			}
+			reserve, err := ParseAmount(r.Reserve)
+			if err != nil {
+				return nil, err
+			}
+			reserves[subTokenID] = reserve.Pip()
+		}
+		subTokens := []NFTSubToken{}
+		for _, subTokenID := range subTokenIDs {
+			if reserve, ok := reserves[subTokenID]; ok {
+				subTokens = append(subTokens, NFTSubToken{ID: subTokenID, Reserve: reserve})
+			}
+		}
+		return subTokens, nil
+	} else {
+		return api.restNFTSubTokens(denom, id, subTokenIDs, api.parallel)
+	}
 }
 
-// Get NFT owned by account
-func (api *API) NFTByAddress(address string) ([]*NFT, error) {
+// NFTStakes requests sub-tokens of NFT delegated by the account with specified address, including unbonding ones.
+// Gateway: ok, REST/RPC: ok
+func (api *API) NFTStakes(address string) ([]*NFTStake, error) {
 	if api.directConn == nil {
-		return api.apiNFTByAddress(address)
+		return api.apiNFTStakes(address)
 	} else {
-		return api.restNFTByAddress(address)
+		return api.restNFTStakesFrom(fmt.Sprintf("/validator/delegators/%s", address))
 	}
 }
 
-func (api *API) apiNFTByAddress(address string) ([]*NFT, error) {
+func (api *API) apiNFTStakes(address string) ([]*NFTStake, error) {
+	addressResult, err := api.apiAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	if addressResult == nil {
+		return nil, fmt.Errorf("address %s is not found", address)
+	}
+	stakes := []*NFTStake{}
+	for _, balance := range addressResult.BalanceNft {
+		byValidator := map[string]*NFTStake{}
+		for _, r := range balance.NftReserve {
+			if !r.Delegated {
+				continue
+			}
+			subTokenID, err := strconv.ParseInt(r.SubTokenId, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid sub-token id %q", r.SubTokenId)
+			}
+			reserve, err := ParseAmount(r.Reserve)
+			if err != nil {
+				return nil, err
+			}
+			key := fmt.Sprintf("%s/%t", r.ValidatorId, r.Unbonded)
+			stake, ok := byValidator[key]
+			if !ok {
+				stake = &NFTStake{
+					Validator:  r.ValidatorId,
+					Delegator:  address,
+					Collection: balance.Collection,
+					NftId:      balance.NftId,
+					Reserve:    sdk.ZeroInt(),
+					Unbonding:  r.Unbonded,
+				}
+				byValidator[key] = stake
+				stakes = append(stakes, stake)
+			}
+			stake.SubTokenIDs = append(stake.SubTokenIDs, subTokenID)
+			stake.Reserve = stake.Reserve.Add(reserve.Pip())
+		}
+	}
+	return stakes, nil
+}
+
+////////////////////////////////////////////////////////////////
+// Direct connection
+////////////////////////////////////////////////////////////////
+
+// nftSubTokensChunk is an amount of sub-tokens requested from the node at once.
+const nftSubTokensChunk = 100
+
+// errLookupDone stops parallel lookup when the item is found.
+var errLookupDone = errors.New("lookup done")
+
+// subTokenIDs decodes sub-token ids encoded by the node as numbers or strings.
+type subTokenIDs []int64
+
+// UnmarshalJSON implements Unmarshaler interface.
+func (ids *subTokenIDs) UnmarshalJSON(data []byte) error {
+	var values []json.Number
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*ids = make(subTokenIDs, len(values))
+	for i, v := range values {
+		id, err := v.Int64()
+		if err != nil {
+			return fmt.Errorf("invalid sub-token id %q", v)
+		}
+		(*ids)[i] = id
+	}
+	return nil
+}
+
+type respDirectNFT struct {
+	Owners struct {
+		Owners []struct {
+			Address     string      `json:"address"`
+			SubTokenIDs subTokenIDs `json:"sub_token_ids"`
+		} `json:"owners"`
+	} `json:"owners"`
+	Creator   string  `json:"creator"`
+	TokenURI  string  `json:"token_uri"`
+	Reserve   sdk.Int `json:"reserve"`
+	AllowMint bool    `json:"allow_mint"`
+}
+
+type respDirectNFTStakes struct {
+	Result struct {
+		Delegations []struct {
+			DelegatorAddress string      `json:"delegator_address"`
+			ValidatorAddress string      `json:"validator_address"`
+			Denom            string      `json:"denom"`
+			TokenID          string      `json:"token_id"`
+			SubTokenIDs      subTokenIDs `json:"sub_token_ids"`
+			Coin             sdk.Coin    `json:"coin"`
+		} `json:"delegations_nft"`
+	} `json:"result"`
+}
+
+type respDirectNFTUnbondingStakes struct {
+	Result struct {
+		Delegations []struct {
+			DelegatorAddress string `json:"delegator_address"`
+			ValidatorAddress string `json:"validator_address"`
+			Entries          []struct {
+				CompletionTime time.Time   `json:"completion_time"`
+				Denom          string      `json:"denom"`
+				TokenID        string      `json:"token_id"`
+				SubTokenIDs    subTokenIDs `json:"sub_token_ids"`
+				Balance        sdk.Coin    `json:"balance"`
+			} `json:"entries"`
+		} `json:"nft_unbonding_delegation"`
+	} `json:"result"`
+}
+
+func (api *API) restNFTDenoms() ([]string, error) {
+	type responseDenomsType struct {
+		Result []string `json:"result"`
+	}
+	//request
+	res, err := api.client.rest.R().Get("/nft/denoms")
+	if err = processConnectionError(res, err); err != nil {
+		return nil, err
+	}
+	//json decode
+	response := responseDenomsType{}
+	err = universalJSONDecode(res.Body(), &response, nil, func() (bool, bool) {
+		return true, false
+	})
+	if err != nil {
+		return nil, err
+	}
+	//process result
+	return response.Result, nil
+}
+
+func (api *API) restNFTCollection(denom string) (map[string]respDirectNFT, error) {
+	type responseType struct {
+		Result map[string]struct {
+			NFTs map[string]respDirectNFT `json:"nfts"`
+		} `json:"result"`
+	}
+	//request
+	res, err := api.client.rest.R().Get(fmt.Sprintf("/nft/collection/%s", denom))
+	if err = processConnectionError(res, err); err != nil {
+		return nil, err
+	}
+	//json decode
+	respValue := responseType{}
+	err = universalJSONDecode(res.Body(), &respValue, nil, func() (bool, bool) {
+		return len(respValue.Result) > 0, false
+	})
+	if err != nil {
+		return nil, err
+	}
+	//process result
+	return respValue.Result[denom].NFTs, nil
+}
+
+// restNFTCollections requests NFT of the collections in parallel.
+func (api *API) restNFTCollections(denoms []string) ([]map[string]respDirectNFT, error) {
+	collections := make([]map[string]respDirectNFT, len(denoms))
+	err := api.parallel(len(denoms), func(i int) error {
+		collection, err := api.restNFTCollection(denoms[i])
+		collections[i] = collection
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
+
+func (api *API) restNFTRecord(denom, id string) (respDirectNFT, error) {
 	type responseType struct {
-		OK     bool `json:"ok"`
 		Result struct {
-			Tokens []*NFT `json:"tokens"`
-		}
+			Type  string        `json:"type"`
+			Value respDirectNFT `json:"value"`
+		} `json:"result"`
 	}
 	//request
-	res, err := api.client.rest.R().Get(fmt.Sprintf("/address/%s/nfts", address))
+	res, err := api.client.rest.R().Get(fmt.Sprintf("/nft/collection/%s/nft/%s", denom, id))
 	if err = processConnectionError(res, err); err != nil {
-		return []*NFT{}, err
+		return respDirectNFT{}, err
 	}
 	//json decode
-	respValue, respErr := responseType{}, Error{}
-	err = universalJSONDecode(res.Body(), &respValue, &respErr, func() (bool, bool) {
-		return respValue.OK, respErr.StatusCode != 0
+	respValue := responseType{}
+	err = universalJSONDecode(res.Body(), &respValue, nil, func() (bool, bool) {
+		return respValue.Result.Type != "", false
 	})
 	if err != nil {
-		return nil, joinErrors(err, respErr)
+		return respDirectNFT{}, err
 	}
 	//process result
-	return respValue.Result.Tokens, nil
+	return respValue.Result.Value, nil
+}
+
+// nftListingTTL is a time during which listing of NFT ids is reused by next pages of NFT list.
+const nftListingTTL = time.Minute
+
+// nftListing contains ids of NFT of collections requested by direct connection. It is built
+// while pages of NFT list are requested, so next pages do not request previous collections again.
+type nftListing struct {
+	denoms  []string
+	items   []nftListingItem // NFT of denoms[:next] sorted by collection and id
+	next    int              // Index of the next collection to request
+	created time.Time
+}
+
+type nftListingItem struct {
+	denom string
+	id    string
+}
+
+func (api *API) restNFTList(opts *ListOptions) ([]*NFTShort, bool, error) {
+	_, end, _ := opts.window(math.MaxInt32)
+	records, err := api.restNFTListing(opts.offset() == 0, end)
+	if err != nil {
+		return nil, false, err
+	}
+	api.nftListingMtx.Lock()
+	listing := api.nftListing.items
+	api.nftListingMtx.Unlock()
+	start, end, hasMore := opts.window(len(listing))
+	items := listing[start:end]
+	if len(items) == 0 {
+		return []*NFTShort{}, hasMore, nil
+	}
+
+	// delegated sub-tokens are not held by owners, they are counted from stakes of the holders
+	result := make([]*NFTShort, len(items))
+	err = api.parallel(len(items), func(i int) error {
+		it := items[i]
+		key := it.denom + "/" + it.id
+		record, ok := records[key]
+		if !ok {
+			var err error
+			if record, err = api.restNFTRecord(it.denom, it.id); err != nil {
+				return err
+			}
+		}
+		stakes, err := api.restNFTStakes(it.denom, it.id, record, sequential)
+		if err != nil {
+			return err
+		}
+		owned, delegated, unbonding := int64(0), int64(0), int64(0)
+		for _, owner := range record.Owners.Owners {
+			owned += int64(len(owner.SubTokenIDs))
+		}
+		for _, s := range stakes {
+			if s.Unbonding {
+				unbonding += int64(len(s.SubTokenIDs))
+			} else {
+				delegated += int64(len(s.SubTokenIDs))
+			}
+		}
+		result[i] = &NFTShort{
+			Id:             it.id,
+			CollectionName: it.denom,
+			Creator:        record.Creator,
+			Quantity:       owned + delegated + unbonding,
+			Delegated:      delegated,
+			Unbound:        unbonding,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return result, hasMore, nil
+}
+
+// restNFTListing requests collections by batches of parallel requests until the listing contains
+// more than end items or all collections are requested. The listing is rebuilt if refresh is set or
+// it is older than nftListingTTL. Records of NFT of collections requested by this call are returned by "denom/id".
+func (api *API) restNFTListing(refresh bool, end int) (map[string]respDirectNFT, error) {
+	api.nftListingMtx.Lock()
+	defer api.nftListingMtx.Unlock()
+	listing := api.nftListing
+	if refresh || listing == nil || time.Since(listing.created) > nftListingTTL {
+		denoms, err := api.restNFTDenoms()
+		if err != nil {
+			return nil, err
+		}
+		listing = &nftListing{denoms: denoms, items: []nftListingItem{}, created: time.Now()}
+		api.nftListing = listing
+	}
+	records := map[string]respDirectNFT{}
+	batch := api.parallelRequests()
+	for listing.next < len(listing.denoms) && len(listing.items) <= end {
+		from, to := listing.next, listing.next+batch
+		if to > len(listing.denoms) {
+			to = len(listing.denoms)
+		}
+		collections, err := api.restNFTCollections(listing.denoms[from:to])
+		if err != nil {
+			return nil, err
+		}
+		for i, collection := range collections {
+			denom := listing.denoms[from+i]
+			ids := make([]string, 0, len(collection))
+			for id := range collection {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+			for _, id := range ids {
+				listing.items = append(listing.items, nftListingItem{denom: denom, id: id})
+				records[denom+"/"+id] = collection[id]
+			}
+		}
+		listing.next = to
+	}
+	return records, nil
 }
 
-//TODO: make full RPC/REST response
 func (api *API) restNFTByAddress(address string) ([]*NFT, error) {
 	type responseType struct {
 		Result struct {
@@ -226,27 +617,12 @@ func (api *API) restNFTByAddress(address string) ([]*NFT, error) {
 			} `json:"value"`
 		} `json:"result"`
 	}
-	type responseNFTType struct {
-		Result struct {
-			Value struct {
-				Owners struct {
-					Owners []struct {
-						Address string `json:"address"`
-						//TODO "sub_token_ids": null
-					} `json:"owners"`
-				} `json:"owners"`
-				Creator   string `json:"creator"`
-				TokenURI  string `json:"token_uri"`
-				Reserve   string `json:"reserve"`
-				AllowMint bool   `json:"allow_mint"`
-			} `json:"value"`
-		} `json:"result"`
-	}
-
+	//request
 	res, err := api.client.rest.R().Get(fmt.Sprintf("/nft/owner/%s", address))
 	if err = processConnectionError(res, err); err != nil {
 		return []*NFT{}, err
 	}
+	//json decode
 	response := responseType{}
 	err = universalJSONDecode(res.Body(), &response, nil, func() (bool, bool) {
 		return true, false
@@ -254,57 +630,279 @@ func (api *API) restNFTByAddress(address string) ([]*NFT, error) {
 	if err != nil {
 		return []*NFT{}, err
 	}
-	result := []*NFT{}
+	//process result
+	type item struct {
+		denom string
+		id    string
+	}
+	items := []item{}
 	for _, col := range response.Result.Value.Collections {
-		// TODO: add REST query by id
-		for _, idd := range col.Ids {
-			base := &NFT{Id: idd, CollectionName: col.Denom}
-			res, err := api.client.rest.R().Get(fmt.Sprintf("/nft/collection/%s/nft/%s", col.Denom, idd))
-			if err = processConnectionError(res, err); err != nil {
-				continue
-			}
-			respValue := responseNFTType{}
-			err = universalJSONDecode(res.Body(), &respValue, nil, func() (bool, bool) {
-				return respValue.Result.Value.Reserve > "", false
+		for _, id := range col.Ids {
+			items = append(items, item{denom: col.Denom, id: id})
+		}
+	}
+	result := make([]*NFT, len(items))
+	err = api.parallel(len(items), func(i int) error {
+		record, err := api.restNFTRecord(items[i].denom, items[i].id)
+		if err != nil {
+			return err
+		}
+		// stakes and sub-tokens are requested inside the worker without nested pool
+		result[i], err = api.directNFT(items[i].denom, items[i].id, record, sequential)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (api *API) restNFT(id string) (*NFT, error) {
+	denoms, err := api.restNFTDenoms()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		denom  string
+		record respDirectNFT
+		mtx    sync.Mutex
+	)
+	err = api.parallel(len(denoms), func(i int) error {
+		collection, err := api.restNFTCollection(denoms[i])
+		if err != nil {
+			return err
+		}
+		if r, ok := collection[id]; ok {
+			mtx.Lock()
+			denom, record = denoms[i], r
+			mtx.Unlock()
+			return errLookupDone
+		}
+		return nil
+	})
+	if err != nil && err != errLookupDone {
+		return nil, err
+	}
+	if denom == "" {
+		return nil, fmt.Errorf("NFT %s is %w", id, ErrNotFound)
+	}
+	return api.directNFT(denom, id, record, api.parallel)
+}
+
+func (api *API) restNFTByCollection(denom, id string) (*NFT, error) {
+	record, err := api.restNFTRecord(denom, id)
+	if err != nil {
+		return nil, err
+	}
+	return api.directNFT(denom, id, record, api.parallel)
+}
+
+// directNFT builds full NFT info from the node record, stakes of delegated sub-tokens and reserves of its sub-tokens.
+// Stakes and reserves of sub-tokens are requested using run.
+func (api *API) directNFT(denom, id string, record respDirectNFT, run runner) (*NFT, error) {
+	stakes, err := api.restNFTStakes(denom, id, record, run)
+	if err != nil {
+		return nil, err
+	}
+	token := &NFT{
+		Id:             id,
+		CollectionName: denom,
+		Creator:        record.Creator,
+		TokenURI:       record.TokenURI,
+		AllowMint:      record.AllowMint,
+		StartReserve:   "0",
+		NFTOwner:       []NFTOwner{},
+		NFTReserve:     []NFTReserve{},
+	}
+	if !record.Reserve.IsNil() {
+		token.StartReserve = record.Reserve.String()
+	}
+	ids := []int64{}
+	for _, owner := range record.Owners.Owners {
+		token.NFTOwner = append(token.NFTOwner, NFTOwner{
+			Address:     owner.Address,
+			Quantity:    strconv.Itoa(len(owner.SubTokenIDs)),
+			SubTokenIDs: owner.SubTokenIDs,
+		})
+		for _, subTokenID := range owner.SubTokenIDs {
+			ids = append(ids, subTokenID)
+			token.NFTReserve = append(token.NFTReserve, NFTReserve{SubTokenId: strconv.FormatInt(subTokenID, 10), Address: owner.Address})
+		}
+	}
+	for _, stake := range stakes {
+		for _, subTokenID := range stake.SubTokenIDs {
+			ids = append(ids, subTokenID)
+			token.NFTReserve = append(token.NFTReserve, NFTReserve{
+				SubTokenId:  strconv.FormatInt(subTokenID, 10),
+				Address:     stake.Delegator,
+				Delegated:   true,
+				ValidatorId: stake.Validator,
+				Unbonded:    stake.Unbonding,
 			})
+		}
+	}
+
+	subTokens, err := api.restNFTSubTokens(denom, id, ids, run)
+	if err != nil {
+		return nil, err
+	}
+	reserves := make(map[string]sdk.Int, len(subTokens))
+	for _, s := range subTokens {
+		reserves[strconv.FormatInt(s.ID, 10)] = s.Reserve
+	}
+	total := sdk.ZeroInt()
+	for i, r := range token.NFTReserve {
+		reserve, ok := reserves[r.SubTokenId]
+		if !ok {
+			reserve = sdk.ZeroInt()
+		}
+		token.NFTReserve[i].Reserve = reserve.String()
+		total = total.Add(reserve)
+	}
+	sort.SliceStable(token.NFTReserve, func(i, j int) bool {
+		a, _ := strconv.ParseInt(token.NFTReserve[i].SubTokenId, 10, 64)
+		b, _ := strconv.ParseInt(token.NFTReserve[j].SubTokenId, 10, 64)
+		return a < b
+	})
+	token.Quantity = strconv.Itoa(len(token.NFTReserve))
+	token.TotalReserve = total.String()
+	return token, nil
+}
+
+// restNFTSubTokens requests reserves of sub-tokens by chunks using the node querier,
+// chunks are requested by run (in parallel unless it is called inside parallel lookup).
+func (api *API) restNFTSubTokens(denom, id string, subTokenIDs []int64, run runner) ([]NFTSubToken, error) {
+	type paramsType struct {
+		Denom       string   `json:"denom"`
+		TokenID     string   `json:"token_id"`
+		SubTokenIDs []string `json:"sub_token_ids"`
+	}
+	type responseType []struct {
+		ID      json.Number `json:"ID"`
+		Reserve sdk.Int     `json:"Reserve"`
+	}
+	chunks := (len(subTokenIDs) + nftSubTokensChunk - 1) / nftSubTokensChunk
+	results := make([][]NFTSubToken, chunks)
+	err := run(chunks, func(i int) error {
+		chunk := subTokenIDs[i*nftSubTokensChunk:]
+		if len(chunk) > nftSubTokensChunk {
+			chunk = chunk[:nftSubTokensChunk]
+		}
+		// node decodes int64 values from strings
+		params := paramsType{Denom: denom, TokenID: id, SubTokenIDs: make([]string, len(chunk))}
+		for j, subTokenID := range chunk {
+			params.SubTokenIDs[j] = strconv.FormatInt(subTokenID, 10)
+		}
+		//request
+		respValue := responseType{}
+		if err := api.rpcABCIQueryWithData("custom/nft/sub_tokens", params, &respValue); err != nil {
+			return err
+		}
+		//process result
+		for _, r := range respValue {
+			subTokenID, err := r.ID.Int64()
 			if err != nil {
-				continue
-			}
-			base.AllowMint = respValue.Result.Value.AllowMint
-			base.TokenURI = respValue.Result.Value.TokenURI
-			base.TotalReserve = respValue.Result.Value.Reserve
-			for _, own := range respValue.Result.Value.Owners.Owners {
-				base.NFTOwner = append(base.NFTOwner, NFTOwner{Address: own.Address})
+				return fmt.Errorf("invalid sub-token id %q", r.ID)
 			}
-			result = append(result, base)
+			results[i] = append(results[i], NFTSubToken{ID: subTokenID, Reserve: r.Reserve})
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return result, nil
+	subTokens := []NFTSubToken{}
+	for _, r := range results {
+		subTokens = append(subTokens, r...)
+	}
+	return subTokens, nil
 }
 
-// Get NTF by id
-// TODO: implement for directConn.
-func (api *API) NFT(id string) (*NFT, error) {
-	if api.directConn != nil {
-		return nil, ErrNotImplemented
+// restNFTStakes requests sub-tokens of the NFT delegated by all its holders using run. Only owners of the NFT
+// are able to delegate its sub-tokens and the node keeps owners even if all their sub-tokens are delegated,
+// so delegations of the owners contain all stakes of the NFT.
+func (api *API) restNFTStakes(denom, id string, record respDirectNFT, run runner) ([]*NFTStake, error) {
+	owners := record.Owners.Owners
+	results := make([][]*NFTStake, len(owners))
+	err := run(len(owners), func(i int) error {
+		stakes, err := api.restNFTStakesFrom(fmt.Sprintf("/validator/delegators/%s", owners[i].Address))
+		if err != nil {
+			return err
+		}
+		for _, s := range stakes {
+			if s.Collection == denom && s.NftId == id {
+				results[i] = append(results[i], s)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	type responseNFTType struct {
-		OK     bool `json:"ok"`
-		Result *NFT `json:"result"`
+	stakes := []*NFTStake{}
+	for _, r := range results {
+		stakes = append(stakes, r...)
 	}
+	return stakes, nil
+}
+
+// restNFTStakesFrom requests NFT delegations and unbonding delegations of the validator
+// ("/validator/validators/{address}") or the delegator ("/validator/delegators/{address}").
+func (api *API) restNFTStakesFrom(path string) ([]*NFTStake, error) {
 	//request
-	res, err := api.client.rest.R().Get(fmt.Sprintf("/nfts/%s", id))
+	res, err := api.client.rest.R().Get(path + "/delegations")
 	if err = processConnectionError(res, err); err != nil {
 		return nil, err
 	}
+	resUnbond, err := api.client.rest.R().Get(path + "/unbonding_delegations")
+	if err = processConnectionError(resUnbond, err); err != nil {
+		return nil, err
+	}
 	//json decode
-	respValue, respErr := responseNFTType{}, Error{}
-	err = universalJSONDecode(res.Body(), &respValue, &respErr, func() (bool, bool) {
-		return respValue.OK, respErr.StatusCode != 0
+	respValue := respDirectNFTStakes{}
+	err = universalJSONDecode(res.Body(), &respValue, nil, func() (bool, bool) {
+		return true, false
 	})
 	if err != nil {
-		return nil, joinErrors(err, respErr)
+		return nil, err
+	}
+	respUnbond := respDirectNFTUnbondingStakes{}
+	err = universalJSONDecode(resUnbond.Body(), &respUnbond, nil, func() (bool, bool) {
+		return true, false
+	})
+	if err != nil {
+		return nil, err
 	}
 	//process result
-	return respValue.Result, nil
+	stakes := []*NFTStake{}
+	for _, d := range respValue.Result.Delegations {
+		stakes = append(stakes, &NFTStake{
+			Validator:   d.ValidatorAddress,
+			Delegator:   d.DelegatorAddress,
+			Collection:  d.Denom,
+			NftId:       d.TokenID,
+			SubTokenIDs: d.SubTokenIDs,
+			Reserve:     d.Coin.Amount,
+		})
+	}
+	for _, d := range respUnbond.Result.Delegations {
+		for _, entry := range d.Entries {
+			stakes = append(stakes, &NFTStake{
+				Validator:      d.ValidatorAddress,
+				Delegator:      d.DelegatorAddress,
+				Collection:     entry.Denom,
+				NftId:          entry.TokenID,
+				SubTokenIDs:    entry.SubTokenIDs,
+				Reserve:        entry.Balance.Amount,
+				Unbonding:      true,
+				CompletionTime: entry.CompletionTime,
+			})
+		}
+	}
+	for _, s := range stakes {
+		if s.Reserve.IsNil() {
+			s.Reserve = sdk.ZeroInt()
+		}
+	}
+	return stakes, nil
 }
@@ -0,0 +1,265 @@
+package api
+
+import (
+	"context"
+	"strconv"
+)
+
+// defaultPageLimit is amount of items requested in one page when limit is not specified.
+const defaultPageLimit = 100
+
+// ListOptions contains pagination and filtering options for list queries.
+type ListOptions struct {
+	Page   uint64 // Number of page starting from 1 (if specified, Offset is calculated from it)
+	Limit  uint64 // Maximum amount of items in one page (defaultPageLimit if not specified)
+	Offset uint64 // Amount of items to skip
+
+	// Filters contains additional server-side filters passed to the gateway as query parameters,
+	// e.g. {"query": "del"} for coins. Filters are ignored by direct connection.
+	Filters map[string]string
+}
+
+// limit returns page size.
+func (opts *ListOptions) limit() uint64 {
+	if opts == nil || opts.Limit == 0 {
+		return defaultPageLimit
+	}
+	return opts.Limit
+}
+
+// offset returns amount of items to skip.
+func (opts *ListOptions) offset() uint64 {
+	if opts == nil {
+		return 0
+	}
+	if opts.Page > 0 {
+		return (opts.Page - 1) * opts.limit()
+	}
+	return opts.Offset
+}
+
+// gatewayParams returns query parameters for the gateway list request.
+func (opts *ListOptions) gatewayParams() map[string]string {
+	params := map[string]string{}
+	if opts == nil {
+		return params
+	}
+	for k, v := range opts.Filters {
+		params[k] = v
+	}
+	params["limit"] = strconv.FormatUint(opts.limit(), 10)
+	params["offset"] = strconv.FormatUint(opts.offset(), 10)
+	return params
+}
+
+// restPage returns page number and page size for the direct REST list request
+// and amount of items to skip in the received page to respect the offset.
+func (opts *ListOptions) restPage() (page, limit, skip uint64) {
+	limit = opts.limit()
+	offset := opts.offset()
+	return offset/limit + 1, limit, offset % limit
+}
+
+// window returns bounds of the page in full list of items with specified length.
+// Nil options means the whole list.
+func (opts *ListOptions) window(length int) (start, end int, hasMore bool) {
+	if opts == nil {
+		return 0, length, false
+	}
+	start, end = int(opts.offset()), int(opts.offset()+opts.limit())
+	if start > length {
+		start = length
+	}
+	if end > length {
+		end = length
+	}
+	return start, end, end < length
+}
+
+////////////////////////////////////////////////////////////////
+// Iterators
+////////////////////////////////////////////////////////////////
+
+// pageFetcher requests single page of items and reports if there are more items after the page.
+type pageFetcher func(opts *ListOptions) (items []interface{}, hasMore bool, err error)
+
+// iterator transparently requests subsequent pages of items.
+type iterator struct {
+	ctx     context.Context
+	fetch   pageFetcher
+	opts    ListOptions
+	items   []interface{}
+	pos     int
+	current interface{}
+	done    bool
+	err     error
+}
+
+func newIterator(ctx context.Context, opts *ListOptions, fetch pageFetcher) iterator {
+	it := iterator{ctx: ctx, fetch: fetch}
+	if opts != nil {
+		it.opts = *opts
+	}
+	it.opts.Limit = it.opts.limit()
+	it.opts.Offset = it.opts.offset()
+	it.opts.Page = 0
+	return it
+}
+
+// next advances iterator to the next item requesting next page if needed.
+func (it *iterator) next() bool {
+	for it.pos >= len(it.items) {
+		if it.done || it.err != nil {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		items, hasMore, err := it.fetch(&it.opts)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items, it.pos = items, 0
+		it.opts.Offset += uint64(len(items))
+		it.done = !hasMore || len(items) == 0
+	}
+	it.current = it.items[it.pos]
+	it.pos++
+	return true
+}
+
+// Err returns error occurred during iteration (including context cancellation).
+func (it *iterator) Err() error {
+	return it.err
+}
+
+// CoinIterator iterates over all coins.
+type CoinIterator struct{ iterator }
+
+// Next advances iterator to the next coin. Returns false when there are no more coins or error occurred.
+func (it *CoinIterator) Next() bool { return it.next() }
+
+// Coin returns current coin.
+func (it *CoinIterator) Coin() *CoinResult { return it.current.(*CoinResult) }
+
+// IterateCoins returns iterator over all coins requesting them page by page.
+func (api *API) IterateCoins(ctx context.Context, opts *ListOptions) *CoinIterator {
+	return &CoinIterator{newIterator(ctx, opts, func(opts *ListOptions) ([]interface{}, bool, error) {
+		coins, hasMore, err := api.coinsPage(opts)
+		items := make([]interface{}, len(coins))
+		for i := range coins {
+			items[i] = coins[i]
+		}
+		return items, hasMore, err
+	})}
+}
+
+// NFTIterator iterates over all NFTs.
+type NFTIterator struct{ iterator }
+
+// Next advances iterator to the next NFT. Returns false when there are no more NFTs or error occurred.
+func (it *NFTIterator) Next() bool { return it.next() }
+
+// NFT returns current NFT.
+func (it *NFTIterator) NFT() *NFTShort { return it.current.(*NFTShort) }
+
+// IterateNFTs returns iterator over all NFTs requesting them page by page.
+func (api *API) IterateNFTs(ctx context.Context, opts *ListOptions) *NFTIterator {
+	return &NFTIterator{newIterator(ctx, opts, func(opts *ListOptions) ([]interface{}, bool, error) {
+		nfts, hasMore, err := api.nftListPage(opts)
+		items := make([]interface{}, len(nfts))
+		for i := range nfts {
+			items[i] = nfts[i]
+		}
+		return items, hasMore, err
+	})}
+}
+
+// ProposalIterator iterates over all proposals.
+type ProposalIterator struct{ iterator }
+
+// Next advances iterator to the next proposal. Returns false when there are no more proposals or error occurred.
+func (it *ProposalIterator) Next() bool { return it.next() }
+
+// Proposal returns current proposal.
+func (it *ProposalIterator) Proposal() ProposalResult { return it.current.(ProposalResult) }
+
+// IterateProposals returns iterator over all proposals requesting them page by page.
+func (api *API) IterateProposals(ctx context.Context, opts *ListOptions) *ProposalIterator {
+	return &ProposalIterator{newIterator(ctx, opts, func(opts *ListOptions) ([]interface{}, bool, error) {
+		proposals, hasMore, err := api.proposalsPage(opts)
+		items := make([]interface{}, len(proposals))
+		for i := range proposals {
+			items[i] = proposals[i]
+		}
+		return items, hasMore, err
+	})}
+}
+
+// ValidatorIterator iterates over all validators.
+type ValidatorIterator struct{ iterator }
+
+// Next advances iterator to the next validator. Returns false when there are no more validators or error occurred.
+func (it *ValidatorIterator) Next() bool { return it.next() }
+
+// Validator returns current validator.
+func (it *ValidatorIterator) Validator() *ValidatorResult { return it.current.(*ValidatorResult) }
+
+// IterateValidators returns iterator over all currently active validators requesting them page by page.
+func (api *API) IterateValidators(ctx context.Context, opts *ListOptions) *ValidatorIterator {
+	return &ValidatorIterator{newIterator(ctx, opts, func(opts *ListOptions) ([]interface{}, bool, error) {
+		validators, hasMore, err := api.validatorsPage(opts)
+		items := make([]interface{}, len(validators))
+		for i := range validators {
+			items[i] = validators[i]
+		}
+		return items, hasMore, err
+	})}
+}
+
+// MultisigTransactionIterator iterates over all transactions in multisig wallet.
+type MultisigTransactionIterator struct{ iterator }
+
+// Next advances iterator to the next transaction. Returns false when there are no more transactions or error occurred.
+func (it *MultisigTransactionIterator) Next() bool { return it.next() }
+
+// Transaction returns current transaction.
+func (it *MultisigTransactionIterator) Transaction() *MultisigTransactionResult {
+	return it.current.(*MultisigTransactionResult)
+}
+
+// IterateMultisigTransactions returns iterator over all transactions in multisig wallet
+// with specified address requesting them page by page.
+func (api *API) IterateMultisigTransactions(ctx context.Context, address string, opts *ListOptions) *MultisigTransactionIterator {
+	return &MultisigTransactionIterator{newIterator(ctx, opts, func(opts *ListOptions) ([]interface{}, bool, error) {
+		txs, hasMore, err := api.multisigTransactionsPage(address, opts)
+		items := make([]interface{}, len(txs))
+		for i := range txs {
+			items[i] = txs[i]
+		}
+		return items, hasMore, err
+	})}
+}
+
+// TxHashIterator iterates over transactions hashes.
+type TxHashIterator struct{ iterator }
+
+// Next advances iterator to the next transaction hash. Returns false when there are no more transactions or error occurred.
+func (it *TxHashIterator) Next() bool { return it.next() }
+
+// Hash returns current transaction hash.
+func (it *TxHashIterator) Hash() string { return it.current.(string) }
+
+// IterateTransactionsByBlock returns iterator over all transactions hashes in block requesting them page by page.
+func (api *API) IterateTransactionsByBlock(ctx context.Context, height uint64, opts *ListOptions) *TxHashIterator {
+	return &TxHashIterator{newIterator(ctx, opts, func(opts *ListOptions) ([]interface{}, bool, error) {
+		hashes, hasMore, err := api.transactionsByBlockPage(height, opts)
+		items := make([]interface{}, len(hashes))
+		for i := range hashes {
+			items[i] = hashes[i]
+		}
+		return items, hasMore, err
+	})}
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"sync"
+)
+
+// defaultParallelRequests is a default limit of concurrent requests made by single lookup on direct connection.
+const defaultParallelRequests = 8
+
+// parallelRequests returns limit of concurrent requests made by single lookup.
+func (api *API) parallelRequests() int {
+	if api.directConn != nil && api.directConn.MaxParallelRequests > 0 {
+		return api.directConn.MaxParallelRequests
+	}
+	return defaultParallelRequests
+}
+
+// runner calls fn for indexes from 0 to n-1 and returns the first error, it is either API.parallel
+// or sequential. Lookups called inside workers of API.parallel use sequential to avoid nested pools.
+type runner func(n int, fn func(i int) error) error
+
+// sequential calls fn for indexes from 0 to n-1 one by one until the first error, which is returned.
+func sequential(n int, fn func(i int) error) error {
+	for i := 0; i < n; i++ {
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parallel calls fn for indexes from 0 to n-1 running limited amount of calls concurrently.
+// Calls which are not started yet are skipped after the first error, which is returned.
+func (api *API) parallel(n int, fn func(i int) error) error {
+	limit := api.parallelRequests()
+	if limit > n {
+		limit = n
+	}
+	var (
+		wg       sync.WaitGroup
+		mtx      sync.Mutex
+		next     int
+		firstErr error
+	)
+	// take returns index of the next call or -1 when all calls are started or failed
+	take := func() int {
+		mtx.Lock()
+		defer mtx.Unlock()
+		if next >= n || firstErr != nil {
+			return -1
+		}
+		next++
+		return next - 1
+	}
+	for w := 0; w < limit; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := take(); i >= 0; i = take() {
+				if err := fn(i); err != nil {
+					mtx.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mtx.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
@@ -0,0 +1,184 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"bitbucket.org/decimalteam/decimal-go-sdk/wallet"
+)
+
+// Limits of proposals checked by the node.
+const (
+	MaxProposalTitleLength       = 140
+	MaxProposalDescriptionLength = 5000
+	MaxVotingBlocks              = 1296000 // Maximum duration of proposal voting in blocks
+)
+
+// ProposalBuilder builds governance proposals validating them against current block height:
+//
+//	result, err := api.NewTextProposal("Title", "Description").VotingBlocks(start, end).Broadcast(ctx, account)
+//
+// Errors of chained methods are collected and returned by Msg or Broadcast.
+type ProposalBuilder struct {
+	api *API
+
+	title       string
+	description string
+
+	startBlock uint64
+	endBlock   uint64
+
+	upgrade    bool
+	planName   string
+	planHeight int64
+	planInfo   string
+	toDownload int64
+
+	errs []error
+}
+
+// NewTextProposal creates builder of text proposal voted by validators in the block range set by VotingBlocks.
+func (api *API) NewTextProposal(title, description string) *ProposalBuilder {
+	return &ProposalBuilder{api: api, title: title, description: description}
+}
+
+// NewSoftwareUpgradeProposal creates builder of software upgrade proposal which schedules
+// upgrade with specified name at the block height. Nodes download new software toDownload blocks before the height.
+// Only the account allowed by the network can submit software upgrade proposals.
+func (api *API) NewSoftwareUpgradeProposal(title, description, name string, height int64, toDownload int64) *ProposalBuilder {
+	return &ProposalBuilder{
+		api:         api,
+		title:       title,
+		description: description,
+		upgrade:     true,
+		planName:    name,
+		planHeight:  height,
+		toDownload:  toDownload,
+	}
+}
+
+// VotingBlocks sets range of blocks in which validators vote for the text proposal.
+func (b *ProposalBuilder) VotingBlocks(start, end uint64) *ProposalBuilder {
+	if b.upgrade {
+		b.errs = append(b.errs, errors.New("software upgrade proposal is not voted"))
+		return b
+	}
+	b.startBlock, b.endBlock = start, end
+	return b
+}
+
+// Info sets application specific information of software upgrade (e.g. links to binaries).
+func (b *ProposalBuilder) Info(info string) *ProposalBuilder {
+	if !b.upgrade {
+		b.errs = append(b.errs, errors.New("only software upgrade proposal contains info"))
+		return b
+	}
+	b.planInfo = info
+	return b
+}
+
+// Msg validates the proposal against current block height and returns message submitting it.
+func (b *ProposalBuilder) Msg(proposer sdk.AccAddress) (sdk.Msg, error) {
+	if len(b.errs) > 0 {
+		return nil, b.errs[0]
+	}
+	if strings.TrimSpace(b.title) == "" || len(b.title) > MaxProposalTitleLength {
+		return nil, fmt.Errorf("proposal title must be not empty and not longer than %d bytes", MaxProposalTitleLength)
+	}
+	if b.description == "" || len(b.description) > MaxProposalDescriptionLength {
+		return nil, fmt.Errorf("proposal description must be not empty and not longer than %d bytes", MaxProposalDescriptionLength)
+	}
+	height, err := b.api.GetHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	var msg sdk.Msg
+	if b.upgrade {
+		if b.planHeight <= int64(height) {
+			return nil, fmt.Errorf("upgrade height %d must be greater than current height %d", b.planHeight, height)
+		}
+		upgrade := MsgSoftwareUpgradeProposal{Title: b.title, Description: b.description, Proposer: proposer}
+		upgrade.Plan.Name = b.planName
+		upgrade.Plan.Height = b.planHeight
+		upgrade.Plan.Info = b.planInfo
+		upgrade.Plan.ToDownload = b.toDownload
+		if err = upgrade.Plan.ValidateBasic(); err != nil {
+			return nil, err
+		}
+		if err = upgrade.ValidateBasic(); err != nil {
+			return nil, fmt.Errorf("proposer %s can not submit software upgrade proposals: %w", proposer, err)
+		}
+		msg = upgrade
+	} else {
+		switch {
+		case b.startBlock <= height:
+			return nil, fmt.Errorf("voting start block %d must be greater than current height %d", b.startBlock, height)
+		case b.endBlock <= b.startBlock:
+			return nil, fmt.Errorf("voting end block %d must be greater than start block %d", b.endBlock, b.startBlock)
+		case b.endBlock-b.startBlock > MaxVotingBlocks:
+			return nil, fmt.Errorf("voting must not be longer than %d blocks, got %d", MaxVotingBlocks, b.endBlock-b.startBlock)
+		}
+		submit := MsgSubmitProposal{Proposer: proposer, VotingStartBlock: b.startBlock, VotingEndBlock: b.endBlock}
+		submit.Content.Title = b.title
+		submit.Content.Description = b.description
+		msg = submit
+	}
+	if err = msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// Broadcast validates the proposal and broadcasts transaction submitting it from the account.
+func (b *ProposalBuilder) Broadcast(ctx context.Context, account *wallet.Account) (*BroadcastTxResult, error) {
+	proposer, err := sdk.AccAddressFromBech32(account.Address())
+	if err != nil {
+		return nil, err
+	}
+	msg, err := b.Msg(proposer)
+	if err != nil {
+		return nil, err
+	}
+	return b.api.NewTxBuilder().Signer(account).Msg(msg).Broadcast(ctx)
+}
+
+// Vote broadcasts transaction with vote of the validator controlled by the account for the proposal.
+// It checks that the validator exists and the proposal is being voted at current block height.
+// Gateway: ok, REST/RPC: ok
+func (api *API) Vote(ctx context.Context, proposalID uint64, option VoteOption, account *wallet.Account) (*BroadcastTxResult, error) {
+	voter, err := sdk.AccAddressFromBech32(account.Address())
+	if err != nil {
+		return nil, err
+	}
+	validator := sdk.ValAddress(voter)
+	if _, err = api.Validator(validator.String()); err != nil {
+		return nil, fmt.Errorf("validator %s of the account is not found: %w", validator, err)
+	}
+	proposal, err := api.Proposal(int64(proposalID))
+	if err != nil {
+		return nil, err
+	}
+	start, err := strconv.ParseUint(proposal.VotingStartBlock, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid voting start block %q of proposal %d", proposal.VotingStartBlock, proposalID)
+	}
+	end, err := strconv.ParseUint(proposal.VotingEndBlock, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid voting end block %q of proposal %d", proposal.VotingEndBlock, proposalID)
+	}
+	height, err := api.GetHeight()
+	if err != nil {
+		return nil, err
+	}
+	if height < start || height > end {
+		return nil, fmt.Errorf("proposal %d is voted in blocks %d-%d, current height is %d", proposalID, start, end, height)
+	}
+	msg := NewMsgVote(validator, proposalID, option)
+	return api.NewTxBuilder().Signer(account).Msg(msg).Broadcast(ctx)
+}
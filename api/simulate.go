@@ -0,0 +1,419 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"bitbucket.org/decimalteam/go-node/utils/formulas"
+)
+
+// sequenceLookup is amount of sequences before and after the current account sequence
+// checked to find out with which sequence the transaction was signed.
+const sequenceLookup = 16
+
+// SimulationProblemKind is a kind of problem found by transaction simulation.
+type SimulationProblemKind string
+
+// Kinds of problems found by transaction simulation.
+const (
+	ProblemInvalidMessage    SimulationProblemKind = "invalid_message"    // Message does not pass basic validation
+	ProblemSignature         SimulationProblemKind = "signature"          // Signature is missing or invalid
+	ProblemSequence          SimulationProblemKind = "sequence"           // Transaction is signed with sequence different from the account one
+	ProblemAccount           SimulationProblemKind = "account"            // Unable to request account of the signer
+	ProblemInsufficientFunds SimulationProblemKind = "insufficient_funds" // Balance does not cover amounts and fees
+	ProblemFee               SimulationProblemKind = "fee"                // Specified fee does not cover required one
+	ProblemUnknownCoin       SimulationProblemKind = "unknown_coin"       // Coin does not exist (or already exists for coin creation)
+	ProblemUnknownValidator  SimulationProblemKind = "unknown_validator"  // Validator does not exist
+)
+
+// SimulationProblem describes single problem found by transaction simulation.
+type SimulationProblem struct {
+	Kind    SimulationProblemKind `json:"kind"`
+	Msg     int                   `json:"msg"`     // Index of the message, -1 for problems of the whole transaction
+	Address string                `json:"address"` // Address of the account, coin symbol or validator address related to the problem
+	Message string                `json:"message"`
+}
+
+// Error returns problem description.
+func (p SimulationProblem) Error() string {
+	if p.Msg < 0 {
+		return fmt.Sprintf("%s: %s", p.Kind, p.Message)
+	}
+	return fmt.Sprintf("message #%d: %s: %s", p.Msg, p.Kind, p.Message)
+}
+
+// SimulationProblems is a list of problems found by transaction simulation.
+type SimulationProblems []SimulationProblem
+
+// Err returns nil if there are no problems or single error describing all of them.
+func (ps SimulationProblems) Err() error {
+	if len(ps) == 0 {
+		return nil
+	}
+	descriptions := make([]string, len(ps))
+	for i, p := range ps {
+		descriptions[i] = p.Error()
+	}
+	return errors.New(strings.Join(descriptions, "; "))
+}
+
+// Simulate checks the transaction locally using current state of the blockchain without broadcasting it:
+// basic validation of messages, signatures against public keys contained in the transaction,
+// account sequences, existence of coins and validators, and balances covering amounts and fees.
+// All found problems are returned, error is returned only when the simulation is not possible
+// (e.g. the gateway or node fails to respond whether the coin or validator exists).
+// Gateway: ok, REST/RPC: ok
+func (api *API) Simulate(tx auth.StdTx) (SimulationProblems, error) {
+	s := &simulation{
+		api:   api,
+		tx:    tx,
+		spent: map[string]sdk.Coins{},
+		coins: map[string]*CoinResult{},
+		empty: map[string][]requiredCoin{},
+	}
+	if err := s.run(); err != nil {
+		return nil, err
+	}
+	return s.problems, nil
+}
+
+// simulation contains state of the transaction simulation.
+type simulation struct {
+	api      *API
+	tx       auth.StdTx
+	baseCoin string
+	problems SimulationProblems
+
+	spent map[string]sdk.Coins      // Coins spent by each account
+	coins map[string]*CoinResult    // Requested coins, nil if the coin does not exist
+	empty map[string][]requiredCoin // Coins which should be present on the balance in any amount
+}
+
+// requiredCoin refers to the coin which the message requires to be present on the balance.
+type requiredCoin struct {
+	msg    int
+	symbol string
+}
+
+func (s *simulation) report(kind SimulationProblemKind, msg int, address string, format string, args ...interface{}) {
+	s.problems = append(s.problems, SimulationProblem{Kind: kind, Msg: msg, Address: address, Message: fmt.Sprintf(format, args...)})
+}
+
+func (s *simulation) run() error {
+	var err error
+	if s.baseCoin, err = s.api.BaseCoin(); err != nil {
+		return err
+	}
+	if len(s.tx.Msgs) == 0 {
+		s.report(ProblemInvalidMessage, -1, "", "transaction contains no messages")
+		return nil
+	}
+	valid := true
+	for i, msg := range s.tx.Msgs {
+		if err := msg.ValidateBasic(); err != nil {
+			s.report(ProblemInvalidMessage, i, "", "%s/%s: %s", msg.Route(), msg.Type(), err.Error())
+			valid = false
+		}
+	}
+	if !valid {
+		// Other checks of invalid messages are meaningless
+		return nil
+	}
+	if err = s.checkSignatures(); err != nil {
+		return err
+	}
+	if err = s.checkFee(); err != nil {
+		return err
+	}
+	for i, msg := range s.tx.Msgs {
+		if err = s.checkMessage(i, msg); err != nil {
+			return err
+		}
+	}
+	s.checkBalances()
+	return nil
+}
+
+// checkSignatures checks that each signer signed the transaction with its current sequence.
+func (s *simulation) checkSignatures() error {
+	signers := s.tx.GetSigners()
+	if len(s.tx.Signatures) == 0 {
+		s.report(ProblemSignature, -1, "", "transaction is not signed")
+		return nil
+	}
+	if len(s.tx.Signatures) != len(signers) {
+		s.report(ProblemSignature, -1, "", "wrong number of signatures: expected %d, got %d", len(signers), len(s.tx.Signatures))
+		return nil
+	}
+	chainID, err := s.api.ChainID()
+	if err != nil {
+		return err
+	}
+	for i, signer := range signers {
+		sig := s.tx.Signatures[i]
+		if sig.PubKey == nil {
+			s.report(ProblemSignature, -1, signer.String(), "signature does not contain public key")
+			continue
+		}
+		if !sdk.AccAddress(sig.PubKey.Address()).Equals(signer) {
+			s.report(ProblemSignature, -1, signer.String(), "public key of the signature belongs to %s", sdk.AccAddress(sig.PubKey.Address()))
+			continue
+		}
+		accountNumber, sequence, err := s.api.AccountNumberAndSequence(signer.String())
+		if err != nil {
+			s.report(ProblemAccount, -1, signer.String(), "unable to request account: %s", err.Error())
+			continue
+		}
+		verify := func(sequence uint64) bool {
+			signBytes := auth.StdSignBytes(chainID, accountNumber, sequence, s.tx.Fee, s.tx.Msgs, s.tx.Memo)
+			return sig.PubKey.VerifyBytes(signBytes, sig.Signature)
+		}
+		if verify(sequence) {
+			continue
+		}
+		from := uint64(0)
+		if sequence > sequenceLookup {
+			from = sequence - sequenceLookup
+		}
+		found := false
+		for seq := from; seq <= sequence+sequenceLookup && !found; seq++ {
+			if seq != sequence && verify(seq) {
+				s.report(ProblemSequence, -1, signer.String(), "transaction is signed with sequence %d, account sequence is %d", seq, sequence)
+				found = true
+			}
+		}
+		if !found {
+			s.report(ProblemSignature, -1, signer.String(), "invalid signature for chain %q, account number %d and sequence %d", chainID, accountNumber, sequence)
+		}
+	}
+	return nil
+}
+
+// checkFee counts fee of the transaction and adds it to coins spent by the fee payer.
+func (s *simulation) checkFee() error {
+	tx := s.tx
+	if len(tx.Signatures) == 0 {
+		tx = withPlaceholderSignature(tx)
+	}
+	gas, err := s.api.EstimateTransactionGasWanted(tx)
+	if err != nil {
+		return err
+	}
+	commission := sdk.NewIntFromUint64(gas).Mul(unitInPip)
+	payer := s.tx.FeePayer().String()
+
+	// Zero fee means the fee is paid in base coin in required amount
+	if s.tx.Fee.Amount.IsZero() {
+		s.spend(payer, sdk.NewCoin(s.baseCoin, commission))
+		return nil
+	}
+	fee := s.tx.Fee.Amount[0]
+	s.spend(payer, fee)
+	if fee.Denom == s.baseCoin {
+		if fee.Amount.LT(commission) {
+			s.report(ProblemFee, -1, payer, "fee %s is less than required %s%s", fee, commission, s.baseCoin)
+		}
+		return nil
+	}
+	coin, err := s.coin(fee.Denom)
+	if err != nil {
+		return err
+	}
+	if coin == nil {
+		s.report(ProblemUnknownCoin, -1, fee.Denom, "fee coin %q does not exist", fee.Denom)
+		return nil
+	}
+	volume, err := coin.VolumeAmount()
+	if err != nil {
+		return err
+	}
+	reserve, err := coin.ReserveAmount()
+	if err != nil {
+		return err
+	}
+	feeInBaseCoin := formulas.CalculateSaleReturn(volume.Pip(), reserve.Pip(), uint(coin.Crr), fee.Amount)
+	if feeInBaseCoin.LT(commission) {
+		s.report(ProblemFee, -1, payer, "fee %s is worth %s%s, required %s%s", fee, feeInBaseCoin, s.baseCoin, commission, s.baseCoin)
+	}
+	return nil
+}
+
+// checkMessage checks existence of coins and validators used by the message
+// and adds coins sent by the message to coins spent by the sender.
+func (s *simulation) checkMessage(i int, msg sdk.Msg) error {
+	switch m := msg.(type) {
+	case MsgSendCoin:
+		return s.spendInMessage(i, m.Sender, m.Coin)
+	case MsgMultiSendCoin:
+		for _, send := range m.Sends {
+			if err := s.spendInMessage(i, m.Sender, send.Coin); err != nil {
+				return err
+			}
+		}
+	case MsgBuyCoin:
+		if err := s.requireCoin(i, m.CoinToBuy.Denom); err != nil {
+			return err
+		}
+		return s.spendInMessage(i, m.Sender, m.MaxCoinToSell)
+	case MsgSellCoin:
+		if err := s.requireCoin(i, m.MinCoinToBuy.Denom); err != nil {
+			return err
+		}
+		return s.spendInMessage(i, m.Sender, m.CoinToSell)
+	case MsgSellAllCoin:
+		if err := s.requireCoin(i, m.MinCoinToBuy.Denom); err != nil {
+			return err
+		}
+		if err := s.requireCoin(i, m.CoinToSell.Denom); err != nil {
+			return err
+		}
+		s.empty[m.Sender.String()] = append(s.empty[m.Sender.String()], requiredCoin{msg: i, symbol: m.CoinToSell.Denom})
+	case MsgBurnCoin:
+		return s.spendInMessage(i, m.Sender, m.Coin)
+	case MsgUpdateCoin:
+		return s.requireCoin(i, strings.ToLower(m.Symbol))
+	case MsgCreateCoin:
+		symbol := strings.ToLower(m.Symbol)
+		coin, err := s.coin(symbol)
+		if err != nil {
+			return err
+		}
+		if coin != nil {
+			s.report(ProblemUnknownCoin, i, symbol, "coin %q already exists", symbol)
+		}
+		s.spend(m.Sender.String(), sdk.NewCoin(s.baseCoin, m.InitialReserve))
+		if payment, err := s.api.getMessageSpecialFee(m); err == nil && payment.Denom != "" {
+			s.spend(m.Sender.String(), payment)
+		}
+	case MsgDeclareCandidate:
+		return s.spendInMessage(i, sdk.AccAddress(m.ValidatorAddr), m.Stake)
+	case MsgEditCandidate:
+		return s.requireValidator(i, m.ValidatorAddress)
+	case MsgDelegate:
+		if err := s.requireValidator(i, m.ValidatorAddress); err != nil {
+			return err
+		}
+		return s.spendInMessage(i, m.DelegatorAddress, m.Coin)
+	case MsgUnbond:
+		if err := s.requireValidator(i, m.ValidatorAddress); err != nil {
+			return err
+		}
+		return s.requireCoin(i, m.Coin.Denom)
+	case MsgSetOnline:
+		return s.requireValidator(i, m.ValidatorAddress)
+	case MsgSetOffline:
+		return s.requireValidator(i, m.ValidatorAddress)
+	case MsgDelegateNFT:
+		return s.requireValidator(i, m.ValidatorAddress)
+	case MsgUnbondNFT:
+		return s.requireValidator(i, m.ValidatorAddress)
+	}
+	return nil
+}
+
+// checkBalances checks that balances of accounts cover all spent coins.
+func (s *simulation) checkBalances() {
+	checked := map[string]bool{}
+	for address := range s.spent {
+		checked[address] = true
+		s.checkBalance(address)
+	}
+	for address := range s.empty {
+		if !checked[address] {
+			s.checkBalance(address)
+		}
+	}
+}
+
+func (s *simulation) checkBalance(address string) {
+	account, err := s.api.Address(address)
+	if err != nil {
+		s.report(ProblemAccount, -1, address, "unable to request account: %s", err.Error())
+		return
+	}
+	balance, err := account.Balances()
+	if err != nil {
+		s.report(ProblemAccount, -1, address, "unable to parse account balance: %s", err.Error())
+		return
+	}
+	for _, coin := range s.spent[address] {
+		if has := balance.AmountOf(coin.Denom); has.LT(coin.Amount) {
+			s.report(ProblemInsufficientFunds, -1, address, "insufficient funds: has %s%s, required %s (amounts and fees)", has, coin.Denom, coin)
+		}
+	}
+	for _, spec := range s.empty[address] {
+		if !balance.AmountOf(spec.symbol).IsPositive() {
+			s.report(ProblemInsufficientFunds, spec.msg, address, "no coins %q to sell", spec.symbol)
+		}
+	}
+}
+
+// spendInMessage checks that the coin exists and adds it to coins spent by the sender.
+func (s *simulation) spendInMessage(i int, sender sdk.AccAddress, coin sdk.Coin) error {
+	if err := s.requireCoin(i, coin.Denom); err != nil {
+		return err
+	}
+	s.spend(sender.String(), coin)
+	return nil
+}
+
+func (s *simulation) spend(address string, coin sdk.Coin) {
+	if coin.Amount.IsPositive() {
+		s.spent[address] = s.spent[address].Add(coin)
+	}
+}
+
+// requireCoin reports problem if the coin does not exist.
+func (s *simulation) requireCoin(i int, symbol string) error {
+	if symbol == "" {
+		return nil
+	}
+	coin, err := s.coin(symbol)
+	if err != nil {
+		return err
+	}
+	if coin == nil {
+		s.report(ProblemUnknownCoin, i, symbol, "coin %q does not exist", symbol)
+	}
+	return nil
+}
+
+// coin returns information about the coin or nil if the coin does not exist.
+// Results are cached during the simulation, errors other than missing coin are returned.
+func (s *simulation) coin(symbol string) (*CoinResult, error) {
+	if symbol == s.baseCoin {
+		return &CoinResult{Symbol: symbol}, nil
+	}
+	if coin, ok := s.coins[symbol]; ok {
+		return coin, nil
+	}
+	coin, err := s.api.Coin(symbol)
+	switch {
+	case IsNotFound(err):
+		coin = nil
+	case err != nil:
+		return nil, fmt.Errorf("unable to request coin %q: %w", symbol, err)
+	case coin == nil || coin.Symbol == "":
+		coin = nil
+	}
+	s.coins[symbol] = coin
+	return coin, nil
+}
+
+// requireValidator reports problem if the validator does not exist.
+func (s *simulation) requireValidator(i int, address sdk.ValAddress) error {
+	validator, err := s.api.Validator(address.String())
+	switch {
+	case IsNotFound(err):
+	case err != nil:
+		return fmt.Errorf("unable to request validator %s: %w", address, err)
+	case validator != nil && validator.Address != "":
+		return nil
+	}
+	s.report(ProblemUnknownValidator, i, address.String(), "validator %s does not exist", address)
+	return nil
+}
@@ -2,6 +2,8 @@ package api
 
 import (
 	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // StakesResponse contains API response.
@@ -30,11 +32,16 @@ type Stake struct {
 }
 
 // Stakes requests full information about stakes from the account with specified address.
-// TODO: implement this API in REST/RPC.
+// Gateway: ok, REST/RPC: partial (NFT stakes are not included)
 func (api *API) Stakes(address string) ([]*StakesResult, error) {
-	if api.directConn != nil {
-		return nil, ErrNotImplemented
+	if api.directConn == nil {
+		return api.apiStakes(address)
+	} else {
+		return api.restStakes(address)
 	}
+}
+
+func (api *API) apiStakes(address string) ([]*StakesResult, error) {
 	//request
 	res, err := api.client.rest.R().Get(fmt.Sprintf("/address/%s/stakes", address))
 	if err = processConnectionError(res, err); err != nil {
@@ -43,12 +50,107 @@ func (api *API) Stakes(address string) ([]*StakesResult, error) {
 	//json decode
 	respValue, respErr := StakesResponse{}, Error{}
 	err = universalJSONDecode(res.Body(), &respValue, &respErr, func() (bool, bool) {
-		return respValue.OK && len(respValue.Result.Stakes) > 0, respErr.StatusCode != 0
+		return respValue.OK, respErr.StatusCode != 0
 	})
-	return nil, respErr
 	if err != nil {
 		return nil, joinErrors(err, respErr)
 	}
 	//process result
 	return respValue.Result.Stakes, nil
 }
+
+type respDirectDelegations struct {
+	Result struct {
+		Delegations []struct {
+			ValidatorAddress string   `json:"validator_address"`
+			Coin             sdk.Coin `json:"coin"`
+			TokensBase       sdk.Int  `json:"tokens_base"`
+		} `json:"delegations"`
+	} `json:"result"`
+}
+
+type respDirectUnbondingDelegations struct {
+	Result struct {
+		Delegations []struct {
+			ValidatorAddress string `json:"validator_address"`
+			Entries          []struct {
+				Balance sdk.Coin `json:"balance"`
+			} `json:"entries"`
+		} `json:"base_unbonding_delegations"`
+	} `json:"result"`
+}
+
+func (api *API) restStakes(address string) ([]*StakesResult, error) {
+	//request
+	res, err := api.client.rest.R().Get(fmt.Sprintf("/validator/delegators/%s/delegations", address))
+	if err = processConnectionError(res, err); err != nil {
+		return nil, err
+	}
+	resUnbond, err := api.client.rest.R().Get(fmt.Sprintf("/validator/delegators/%s/unbonding_delegations", address))
+	if err = processConnectionError(resUnbond, err); err != nil {
+		return nil, err
+	}
+	//json decode
+	respValue := respDirectDelegations{}
+	err = universalJSONDecode(res.Body(), &respValue, nil, func() (bool, bool) {
+		return true, false
+	})
+	if err != nil {
+		return nil, err
+	}
+	respUnbond := respDirectUnbondingDelegations{}
+	err = universalJSONDecode(resUnbond.Body(), &respUnbond, nil, func() (bool, bool) {
+		return true, false
+	})
+	if err != nil {
+		return nil, err
+	}
+	//process result
+	results := []*StakesResult{}
+	byValidator := map[string]*StakesResult{}
+	totals := map[string]sdk.Int{}
+	stake := func(validator string, coin string) *Stake {
+		result, ok := byValidator[validator]
+		if !ok {
+			result = &StakesResult{ValidatorID: validator, Stakes: []*Stake{}}
+			byValidator[validator] = result
+			results = append(results, result)
+			totals[validator] = sdk.ZeroInt()
+		}
+		for _, s := range result.Stakes {
+			if s.Coin == coin {
+				return s
+			}
+		}
+		s := &Stake{Coin: coin, Amount: "0", BaseAmount: "0", UnbondAmount: "0"}
+		result.Stakes = append(result.Stakes, s)
+		return s
+	}
+	for _, d := range respValue.Result.Delegations {
+		s := stake(d.ValidatorAddress, d.Coin.Denom)
+		s.Amount = d.Coin.Amount.String()
+		if !d.TokensBase.IsNil() {
+			s.BaseAmount = d.TokensBase.String()
+			totals[d.ValidatorAddress] = totals[d.ValidatorAddress].Add(d.TokensBase)
+		}
+	}
+	for _, d := range respUnbond.Result.Delegations {
+		for _, entry := range d.Entries {
+			s := stake(d.ValidatorAddress, entry.Balance.Denom)
+			unbond, ok := sdk.NewIntFromString(s.UnbondAmount)
+			if !ok {
+				unbond = sdk.ZeroInt()
+			}
+			s.UnbondAmount = unbond.Add(entry.Balance.Amount).String()
+		}
+	}
+	for _, result := range results {
+		result.TotalStake = totals[result.ValidatorID].String()
+		validator, err := api.restValidator(result.ValidatorID)
+		if err != nil {
+			return nil, err
+		}
+		result.Validator = validator
+	}
+	return results, nil
+}
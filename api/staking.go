@@ -0,0 +1,296 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"bitbucket.org/decimalteam/decimal-go-sdk/wallet"
+	"bitbucket.org/decimalteam/go-node/utils/formulas"
+)
+
+// DefaultMaxDelegations is amount of delegator slots of the validator used when node parameter
+// "max_delegations" can not be requested (gateway does not provide validator parameters).
+const DefaultMaxDelegations = 1000
+
+// StakePosition contains stakes of the delegator in single validator.
+type StakePosition struct {
+	Validator string       `json:"validator"` // Address with prefix "dxvaloper"
+	Moniker   string       `json:"moniker"`
+	Coins     []StakedCoin `json:"coins"`
+	Total     sdk.Int      `json:"total"` // Total stake in base coin (pip)
+}
+
+// StakedCoin contains stake of the delegator in single coin.
+type StakedCoin struct {
+	Coin      sdk.Coin `json:"coin"`
+	Base      sdk.Int  `json:"base"`      // Equivalent of the stake in base coin (pip)
+	Unbonding sdk.Int  `json:"unbonding"` // Amount of coins being unbonded (pip)
+}
+
+// Stake returns staked coin with specified symbol or nil if the position does not contain it.
+func (p *StakePosition) Stake(symbol string) *StakedCoin {
+	symbol = strings.ToLower(symbol)
+	for i := range p.Coins {
+		if p.Coins[i].Coin.Denom == symbol {
+			return &p.Coins[i]
+		}
+	}
+	return nil
+}
+
+// StakePositions requests stakes of the delegator with specified address grouped by validators.
+// Gateway: ok, REST/RPC: see Stakes
+func (api *API) StakePositions(address string) ([]*StakePosition, error) {
+	stakes, err := api.Stakes(address)
+	if err != nil {
+		return nil, err
+	}
+	positions := make([]*StakePosition, 0, len(stakes))
+	for _, s := range stakes {
+		total, err := s.TotalStakeAmount()
+		if err != nil {
+			return nil, err
+		}
+		position := &StakePosition{Validator: s.ValidatorID, Total: total.Pip(), Coins: make([]StakedCoin, 0, len(s.Stakes))}
+		if s.Validator != nil {
+			position.Moniker = s.Validator.Moniker
+		}
+		for _, stake := range s.Stakes {
+			amount, err := stake.AmountValue()
+			if err != nil {
+				return nil, err
+			}
+			base, err := stake.BaseAmountValue()
+			if err != nil {
+				return nil, err
+			}
+			unbonding, err := stake.UnbondAmountValue()
+			if err != nil {
+				return nil, err
+			}
+			position.Coins = append(position.Coins, StakedCoin{
+				Coin:      sdk.Coin{Denom: strings.ToLower(stake.Coin), Amount: amount.Pip()},
+				Base:      base.Pip(),
+				Unbonding: unbonding.Pip(),
+			})
+		}
+		positions = append(positions, position)
+	}
+	return positions, nil
+}
+
+// MaxDelegations returns amount of delegator slots of the validator (node parameter "max_delegations").
+// When all slots are used, new stake is accepted only if it exceeds the minimum stake of the validator.
+// Requested value is remembered by the API instance.
+// Gateway: partial (DefaultMaxDelegations is returned), REST/RPC: ok
+func (api *API) MaxDelegations() (uint64, error) {
+	if api.directConn == nil {
+		return DefaultMaxDelegations, nil
+	}
+	api.mtx.RLock()
+	maxDelegations := api.maxDeleg
+	api.mtx.RUnlock()
+	if maxDelegations != 0 {
+		return maxDelegations, nil
+	}
+	maxDelegations, err := api.restMaxDelegations()
+	if err != nil {
+		return 0, err
+	}
+	api.mtx.Lock()
+	api.maxDeleg = maxDelegations
+	api.mtx.Unlock()
+	return maxDelegations, nil
+}
+
+func (api *API) restMaxDelegations() (uint64, error) {
+	type responseType struct {
+		Result struct {
+			MaxDelegations jsonUint `json:"max_delegations"`
+		} `json:"result"`
+	}
+	//request
+	res, err := api.client.rest.R().Get("/validator/parameters")
+	if err = processConnectionError(res, err); err != nil {
+		return 0, err
+	}
+	//json decode
+	respValue := responseType{}
+	err = universalJSONDecode(res.Body(), &respValue, nil, func() (bool, bool) {
+		return respValue.Result.MaxDelegations > 0, false
+	})
+	if err != nil {
+		return 0, err
+	}
+	//process result
+	return uint64(respValue.Result.MaxDelegations), nil
+}
+
+// validatorSlots returns amount of delegator slots used in the validator. Gateway reports it
+// in validator info, on direct connection delegations (coins and NFT) of the validator are counted.
+func (api *API) validatorSlots(val *ValidatorResult) (uint64, error) {
+	if api.directConn == nil {
+		return val.Slots, nil
+	}
+	type responseType struct {
+		Result struct {
+			Delegations    []json.RawMessage `json:"delegations"`
+			DelegationsNFT []json.RawMessage `json:"delegations_nft"`
+		} `json:"result"`
+	}
+	//request
+	res, err := api.client.rest.R().Get(fmt.Sprintf("/validator/validators/%s/delegations", val.Address))
+	if err = processConnectionError(res, err); err != nil {
+		return 0, err
+	}
+	//json decode
+	respValue := responseType{}
+	err = universalJSONDecode(res.Body(), &respValue, nil, func() (bool, bool) {
+		return true, false
+	})
+	if err != nil {
+		return 0, err
+	}
+	//process result
+	return uint64(len(respValue.Result.Delegations) + len(respValue.Result.DelegationsNFT)), nil
+}
+
+// CheckDelegation checks that the validator with specified address ("dxvaloper...") is online
+// and accepts stake of the delegator: there is free delegator slot or the stake in base coin
+// exceeds minimum stake of the validator (or the delegator already has stake in this coin).
+// Gateway: ok (default amount of slots), REST/RPC: ok (used slots are counted from delegations)
+func (api *API) CheckDelegation(delegator string, validator string, coin sdk.Coin) error {
+	if !coin.Amount.IsPositive() {
+		return fmt.Errorf("stake amount must be positive, got %s", coin)
+	}
+	val, err := api.Validator(validator)
+	if err != nil {
+		return err
+	}
+	if val.Status != "online" {
+		return fmt.Errorf("validator %s is %s", validator, val.Status)
+	}
+	maxDelegations, err := api.MaxDelegations()
+	if err != nil {
+		return err
+	}
+	slots, err := api.validatorSlots(val)
+	if err != nil {
+		return fmt.Errorf("delegator slots of validator %s are unknown: %w", validator, err)
+	}
+	if slots < maxDelegations {
+		return nil
+	}
+	positions, err := api.StakePositions(delegator)
+	if err != nil {
+		return err
+	}
+	for _, p := range positions {
+		if p.Validator == validator && p.Stake(coin.Denom) != nil {
+			return nil
+		}
+	}
+	minStake, err := val.MinStakeAmount()
+	if err != nil {
+		return err
+	}
+	base, err := api.StakeBaseValue(coin)
+	if err != nil {
+		return err
+	}
+	if base.LTE(minStake.Pip()) {
+		return fmt.Errorf("all %d delegator slots of validator %s are used and stake %s (%s pip in base coin) does not exceed minimum stake %s pip",
+			maxDelegations, validator, coin, base, minStake)
+	}
+	return nil
+}
+
+// StakeBaseValue returns equivalent of the stake in base coin (pip).
+// Custom coin is valued by its sale return.
+// Gateway: ok, REST/RPC: ok
+func (api *API) StakeBaseValue(coin sdk.Coin) (sdk.Int, error) {
+	baseCoin, err := api.BaseCoin()
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	if strings.ToLower(coin.Denom) == baseCoin {
+		return coin.Amount, nil
+	}
+	c, err := api.Coin(coin.Denom)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	volume, err := c.VolumeAmount()
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	reserve, err := c.ReserveAmount()
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	return formulas.CalculateSaleReturn(volume.Pip(), reserve.Pip(), uint(c.Crr), coin.Amount), nil
+}
+
+// checkUnbond checks that the delegator has enough stake in the validator to unbond the coin.
+func (api *API) checkUnbond(delegator string, validator string, coin sdk.Coin) error {
+	if !coin.Amount.IsPositive() {
+		return fmt.Errorf("unbond amount must be positive, got %s", coin)
+	}
+	positions, err := api.StakePositions(delegator)
+	if err != nil {
+		return err
+	}
+	for _, p := range positions {
+		if p.Validator != validator {
+			continue
+		}
+		if stake := p.Stake(coin.Denom); stake != nil {
+			if stake.Coin.Amount.LT(coin.Amount) {
+				return fmt.Errorf("stake %s in validator %s is less than %s", stake.Coin, validator, coin)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("no stake in %s in validator %s", coin.Denom, validator)
+}
+
+// Delegate checks the validator (see CheckDelegation) and broadcasts transaction delegating coin
+// from the account to the validator with specified address ("dxvaloper...").
+// Gateway: ok, REST/RPC: partial
+func (api *API) Delegate(ctx context.Context, account *wallet.Account, validator string, coin sdk.Coin) (*BroadcastTxResult, error) {
+	if err := api.CheckDelegation(account.Address(), validator, coin); err != nil {
+		return nil, err
+	}
+	return api.NewTxBuilder().Signer(account).Delegate(validator, coin).Broadcast(ctx)
+}
+
+// Unbond checks stake of the account and broadcasts transaction unbonding coin from the validator
+// with specified address ("dxvaloper..."). Coins are returned to the account after unbonding period.
+// Gateway: ok, REST/RPC: see Stakes
+func (api *API) Unbond(ctx context.Context, account *wallet.Account, validator string, coin sdk.Coin) (*BroadcastTxResult, error) {
+	if err := api.checkUnbond(account.Address(), validator, coin); err != nil {
+		return nil, err
+	}
+	return api.NewTxBuilder().Signer(account).Unbond(validator, coin).Broadcast(ctx)
+}
+
+// Redistribute broadcasts single transaction unbonding coin from one validator and delegating
+// the same coin to another one. Unbonded coins are returned after unbonding period,
+// so the new stake is paid from the current balance of the account.
+// Gateway: ok, REST/RPC: see Stakes
+func (api *API) Redistribute(ctx context.Context, account *wallet.Account, from string, to string, coin sdk.Coin) (*BroadcastTxResult, error) {
+	if from == to {
+		return nil, fmt.Errorf("unable to redistribute stake to the same validator %s", from)
+	}
+	if err := api.checkUnbond(account.Address(), from, coin); err != nil {
+		return nil, err
+	}
+	if err := api.CheckDelegation(account.Address(), to, coin); err != nil {
+		return nil, err
+	}
+	return api.NewTxBuilder().Signer(account).Unbond(from, coin).Delegate(to, coin).Broadcast(ctx)
+}
@@ -1,10 +1,12 @@
 package api
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 // TransactionResponse contains API response.
@@ -123,14 +125,25 @@ func (api *API) restTransaction(txHash string) (*TransactionResult, error) {
 
 //TransactionsByBlock return all transactions hashes in block
 func (api *API) TransactionsByBlock(height uint64) ([]string, error) {
+	hashes, _, err := api.transactionsByBlockPage(height, nil)
+	return hashes, err
+}
+
+// TransactionsByBlockWithOptions return transactions hashes in block in the page specified by options
+func (api *API) TransactionsByBlockWithOptions(height uint64, opts *ListOptions) ([]string, error) {
+	hashes, _, err := api.transactionsByBlockPage(height, opts)
+	return hashes, err
+}
+
+func (api *API) transactionsByBlockPage(height uint64, opts *ListOptions) ([]string, bool, error) {
 	if api.directConn == nil {
-		return api.apiTransactionsByBlock(height)
+		return api.apiTransactionsByBlock(height, opts)
 	} else {
-		return api.restTransactionsByBlock(height)
+		return api.restTransactionsByBlock(height, opts)
 	}
 }
 
-func (api *API) apiTransactionsByBlock(height uint64) ([]string, error) {
+func (api *API) apiTransactionsByBlock(height uint64, opts *ListOptions) ([]string, bool, error) {
 	type responseType struct {
 		OK     bool `json:"ok"`
 		Result struct {
@@ -141,9 +154,9 @@ func (api *API) apiTransactionsByBlock(height uint64) ([]string, error) {
 		} `json:"result"`
 	}
 	//request
-	res, err := api.client.rpc.R().Get(fmt.Sprintf("/block/%d/txs", height))
+	res, err := api.client.rpc.R().SetQueryParams(opts.gatewayParams()).Get(fmt.Sprintf("/block/%d/txs", height))
 	if err = processConnectionError(res, err); err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	//json decode
 	respValue, respErr := responseType{}, JsonRPCError{}
@@ -151,17 +164,18 @@ func (api *API) apiTransactionsByBlock(height uint64) ([]string, error) {
 		return respValue.OK, respErr.InternalError.Code != 0
 	})
 	if err != nil {
-		return nil, joinErrors(err, respErr)
+		return nil, false, joinErrors(err, respErr)
 	}
 	//process result
-	result := make([]string, 0, respValue.Result.Count)
+	result := make([]string, 0, len(respValue.Result.Txs))
 	for _, tx := range respValue.Result.Txs {
 		result = append(result, tx.Hash)
 	}
-	return result, nil
+	hasMore := opts.offset()+uint64(len(result)) < uint64(respValue.Result.Count)
+	return result, hasMore, nil
 }
 
-func (api *API) restTransactionsByBlock(height uint64) ([]string, error) {
+func (api *API) restTransactionsByBlock(height uint64, opts *ListOptions) ([]string, bool, error) {
 	type responseType struct {
 		TotalCount string `json:"total_count"`
 		Count      string `json:"count"`
@@ -169,10 +183,20 @@ func (api *API) restTransactionsByBlock(height uint64) ([]string, error) {
 			Hash string `json:"txhash"`
 		} `json:"txs"`
 	}
+	// without options all transactions are requested page by page
+	if opts == nil {
+		result := []string{}
+		it := api.IterateTransactionsByBlock(context.Background(), height, nil)
+		for it.Next() {
+			result = append(result, it.Hash())
+		}
+		return result, false, it.Err()
+	}
+	page, limit, skip := opts.restPage()
 	// request
-	res, err := api.client.rest.R().Get(fmt.Sprintf("/txs?tx.minheight=%d&tx.maxheight=%d&limit=%d", height, height, 1000))
+	res, err := api.client.rest.R().Get(fmt.Sprintf("/txs?tx.minheight=%d&tx.maxheight=%d&page=%d&limit=%d", height, height, page, limit))
 	if err = processConnectionError(res, err); err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	// json decode
 	respValue := responseType{}
@@ -180,16 +204,80 @@ func (api *API) restTransactionsByBlock(height uint64) ([]string, error) {
 		return respValue.Count > "", false
 	})
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	// process result
-	//TODO: pagination
 	totalCount, _ := strconv.ParseUint(respValue.TotalCount, 10, 64)
-	result := make([]string, 0, totalCount)
-	for _, tx := range respValue.Txs {
+	result := make([]string, 0, len(respValue.Txs))
+	for i, tx := range respValue.Txs {
+		if uint64(i) < skip {
+			continue
+		}
 		result = append(result, tx.Hash)
 	}
-	return result, nil
+	hasMore := (page-1)*limit+uint64(len(respValue.Txs)) < totalCount
+	return result, hasMore, nil
+}
+
+// txSearchResult contains Tendermint RPC `tx_search` response fields.
+type txSearchResult struct {
+	Txs        []*TransactionResult `json:"txs"`
+	TotalCount jsonUint             `json:"total_count"`
+}
+
+// rpcTxSearch requests one page of transactions matching specified Tendermint query.
+// Both gateway and direct connection proxy Tendermint RPC `tx_search` method.
+func (api *API) rpcTxSearch(query string, page, perPage int, orderBy string) (*txSearchResult, error) {
+	type responseType struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      int64           `json:"id"`
+		Result  *txSearchResult `json:"result"`
+	}
+	url := "/tx_search"
+	if api.directConn == nil {
+		url = "/rpc/tx_search"
+	}
+	//request
+	res, err := api.client.rpc.R().SetQueryParams(map[string]string{
+		"query":    fmt.Sprintf("%q", query),
+		"page":     strconv.Itoa(page),
+		"per_page": strconv.Itoa(perPage),
+		"order_by": fmt.Sprintf("%q", orderBy),
+	}).Get(url)
+	if err = processConnectionError(res, err); err != nil {
+		return nil, err
+	}
+	//json decode
+	respValue, respErr := responseType{}, JsonRPCError{}
+	err = universalJSONDecode(res.Body(), &respValue, &respErr, func() (bool, bool) {
+		return respValue.Result != nil, respErr.InternalError.Code != 0
+	})
+	if err != nil {
+		return nil, joinErrors(err, respErr)
+	}
+	//process result
+	for _, tx := range respValue.Result.Txs {
+		if tx.TxResult == nil {
+			continue
+		}
+		txLogs := []TxLog{}
+		json.Unmarshal([]byte(tx.TxResult.Log), &txLogs)
+		tx.TxResult.LogParsed = txLogs
+	}
+	return respValue.Result, nil
+}
+
+// jsonUint is unsigned integer which can be presented in JSON as number or as string.
+type jsonUint uint64
+
+// UnmarshalJSON implements Unmarshaler interface.
+func (u *jsonUint) UnmarshalJSON(b []byte) error {
+	value, err := strconv.ParseUint(strings.Trim(string(b), `"`), 10, 64)
+	if err != nil {
+		return err
+	}
+	*u = jsonUint(value)
+	return nil
 }
 
 ////////////////////////////////////////////////////////////////
@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"strconv"
 )
 
 // ValidatorResponse contains API response.
@@ -38,7 +39,7 @@ type ValidatorResult struct {
 	// TODO: this info missing in REST.
 	BlockID       uint64 `json:"blockId"`       // Number of block in which the validator was declared
 	SkippedBlocks uint64 `json:"skippedBlocks"` // Amount of blocks missed to sign
-	Slots         uint64 `json:"slots"`         // Amount of delegator slots used
+	Slots         uint64 `json:"slots"`         // Amount of delegator slots used (gateway only)
 	MinStake      string `json:"mins"`          // Minimum stake needed to get place in the delegators list
 	Rating        string `json:"rating"`        // Rating of the validator
 	Status        string `json:"status"`        // Current status of the validator (online, offline)
@@ -50,7 +51,7 @@ type respDirectValidator struct {
 	RewardAddress    string `json:"reward_address"` // Address with prefix "dx" to receive rewards for participating block producing and consensus
 	ConsensusAddress string `json:"pub_key"`        // Address with prefix "dxvalcons" used only for consensus
 	Stake            string `json:"stake_coins"`    // Total stake of the validator
-	Status           int    `json:"status"`         // TODO: kind of validator?
+	Status           int    `json:"status"`         // Bond status: 0 - unbonded, 1 - unbonding, 2 - bonded
 	Online           bool   `json:"online"`         // online/offline
 	// TODO: comMission and comission?
 	Comission   string `json:"commission"` // Specified by the validator operator
@@ -63,6 +64,18 @@ type respDirectValidator struct {
 	} `json:"description"`
 }
 
+// bondStatusBonded is a bond status of validators participating in consensus in node responses.
+const bondStatusBonded = 2
+
+// Names of bond statuses used to filter validators in node requests.
+const (
+	bondStatusNameUnbonded  = "Unbonded"
+	bondStatusNameUnbonding = "Unbonding"
+)
+
+// candidatesPageLimit is a page size used to request all candidates from the node.
+const candidatesPageLimit = 100
+
 type respDirectValidators struct {
 	Result []respDirectValidator `json:"result"`
 }
@@ -76,6 +89,10 @@ func directResponse2Validator(dres respDirectValidator) *ValidatorResult {
 	if !dres.Online {
 		onlineStatus = "offline"
 	}
+	kind := "Validator"
+	if dres.Status != bondStatusBonded {
+		kind = "Candidate"
+	}
 	return &ValidatorResult{
 		Address:          dres.Address,
 		RewardAddress:    dres.RewardAddress,
@@ -89,18 +106,22 @@ func directResponse2Validator(dres respDirectValidator) *ValidatorResult {
 		Stake:            dres.Stake,
 		Power:            power,
 		Status:           onlineStatus,
-		Kind:             "Validator", // TODO: replace after releasing candidates
+		Kind:             kind,
 	}
 
 }
 
 // Candidates requests full list of candidates (validators which does not participate in block production and consensus).
-// Gateway: ok, REST/RPC: none
+// Gateway: ok, REST/RPC: partial
 func (api *API) Candidates() ([]*ValidatorResult, error) {
-	// TODO: implement candidates in REST.
-	if api.directConn != nil {
-		return nil, ErrNotImplemented
+	if api.directConn == nil {
+		return api.apiCandidates()
+	} else {
+		return api.restCandidates()
 	}
+}
+
+func (api *API) apiCandidates() ([]*ValidatorResult, error) {
 	//request
 	res, err := api.client.rest.R().Get("/validators/candidate")
 	if err = processConnectionError(res, err); err != nil {
@@ -118,21 +139,67 @@ func (api *API) Candidates() ([]*ValidatorResult, error) {
 	return respValue.Result.Validators, nil
 }
 
+func (api *API) restCandidates() ([]*ValidatorResult, error) {
+	candidates := []*ValidatorResult{}
+	// node returns validators with single bond status per request
+	for _, status := range []string{bondStatusNameUnbonded, bondStatusNameUnbonding} {
+		for page := 1; ; page++ {
+			//request
+			res, err := api.client.rest.R().SetQueryParams(map[string]string{
+				"status": status,
+				"page":   strconv.Itoa(page),
+				"limit":  strconv.Itoa(candidatesPageLimit),
+			}).Get("/validator/validators")
+			if err = processConnectionError(res, err); err != nil {
+				return nil, err
+			}
+			//json decode
+			respValue := respDirectValidators{}
+			err = universalJSONDecode(res.Body(), &respValue, nil, func() (bool, bool) {
+				return respValue.Result != nil, false
+			})
+			if err != nil {
+				return nil, err
+			}
+			//process result
+			for _, val := range respValue.Result {
+				candidates = append(candidates, directResponse2Validator(val))
+			}
+			if len(respValue.Result) < candidatesPageLimit {
+				break
+			}
+		}
+	}
+	return candidates, nil
+}
+
 // Validators requests full list of currently active validators.
 // Gateway: ok, REST/RPC: partial
 func (api *API) Validators() ([]*ValidatorResult, error) {
+	validators, _, err := api.validatorsPage(nil)
+	return validators, err
+}
+
+// ValidatorsWithOptions requests currently active validators in the page specified by options.
+// Gateway: ok, REST/RPC: partial
+func (api *API) ValidatorsWithOptions(opts *ListOptions) ([]*ValidatorResult, error) {
+	validators, _, err := api.validatorsPage(opts)
+	return validators, err
+}
+
+func (api *API) validatorsPage(opts *ListOptions) ([]*ValidatorResult, bool, error) {
 	if api.directConn == nil {
-		return api.apiValidators()
+		return api.apiValidators(opts)
 	} else {
-		return api.restValidators()
+		return api.restValidators(opts)
 	}
 }
 
-func (api *API) apiValidators() ([]*ValidatorResult, error) {
+func (api *API) apiValidators(opts *ListOptions) ([]*ValidatorResult, bool, error) {
 	//request
-	res, err := api.client.rest.R().Get("/validators/validator")
+	res, err := api.client.rest.R().SetQueryParams(opts.gatewayParams()).Get("/validators/validator")
 	if err = processConnectionError(res, err); err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	//json decode
 	respValue, respErr := ValidatorsResponse{}, Error{}
@@ -140,32 +207,46 @@ func (api *API) apiValidators() ([]*ValidatorResult, error) {
 		return respValue.OK, respErr.StatusCode != 0
 	})
 	if err != nil {
-		return nil, joinErrors(err, respErr)
+		return nil, false, joinErrors(err, respErr)
 	}
 	//process result
-	return respValue.Result.Validators, nil
+	hasMore := opts.offset()+uint64(len(respValue.Result.Validators)) < uint64(respValue.Result.Count)
+	return respValue.Result.Validators, hasMore, nil
 }
-func (api *API) restValidators() ([]*ValidatorResult, error) {
+
+func (api *API) restValidators(opts *ListOptions) ([]*ValidatorResult, bool, error) {
 	//request
-	res, err := api.client.rest.R().Get("/validator/validators")
+	req := api.client.rest.R()
+	skip := uint64(0)
+	if opts != nil {
+		var page, limit uint64
+		page, limit, skip = opts.restPage()
+		req.SetQueryParam("page", strconv.FormatUint(page, 10))
+		req.SetQueryParam("limit", strconv.FormatUint(limit, 10))
+	}
+	res, err := req.Get("/validator/validators")
 	if err = processConnectionError(res, err); err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	//json decode
 	respValue := respDirectValidators{}
 	err = universalJSONDecode(res.Body(), &respValue, nil, func() (bool, bool) {
-		return len(respValue.Result) > 0, false
+		return respValue.Result != nil, false
 	})
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	//process result
 	validators := []*ValidatorResult{}
-	for _, val := range respValue.Result {
+	for i, val := range respValue.Result {
+		if uint64(i) < skip {
+			continue
+		}
 		validators = append(validators, directResponse2Validator(val))
 	}
-
-	return validators, nil
+	// node does not return total count, so full page means there may be more items
+	hasMore := opts != nil && uint64(len(respValue.Result)) == opts.limit()
+	return validators, hasMore, nil
 }
 
 // Validator requests full information about validator with specified address.
@@ -0,0 +1,286 @@
+// Package checkbook provides batch check issuance and tracking of issued checks.
+package checkbook
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	decapi "bitbucket.org/decimalteam/decimal-go-sdk/api"
+	"bitbucket.org/decimalteam/decimal-go-sdk/wallet"
+)
+
+// CheckStatus is a status of the issued check.
+type CheckStatus string
+
+// Check statuses.
+const (
+	StatusIssued   CheckStatus = "issued"   // Check is issued and can be redeemed
+	StatusRedeemed CheckStatus = "redeemed" // Check is redeemed in the blockchain
+	StatusExpired  CheckStatus = "expired"  // Check due block has passed and it can't be redeemed anymore
+)
+
+// IssuedCheck contains info about check issued by the check book.
+type IssuedCheck struct {
+	Nonce          string      `json:"nonce"`          // Check nonce presented as decimal number
+	Coin           string      `json:"coin"`           // Symbol of coin
+	Amount         string      `json:"amount"`         // Amount of coins in pip
+	DueBlock       uint64      `json:"dueBlock"`       // Number of block until which the check is valid
+	Check          string      `json:"check"`          // Check encoded to base58 format
+	PassphraseHash string      `json:"passphraseHash"` // SHA256 hash of passphrase presented in hex format
+	Passphrase     string      `json:"-"`              // Passphrase (never persisted, filled only for just issued checks)
+	Status         CheckStatus `json:"status"`
+	IssuedAt       time.Time   `json:"issuedAt"`
+	RedeemTxHash   string      `json:"redeemTxHash,omitempty"` // Hash of transaction in which the check was redeemed
+	RedeemedBy     string      `json:"redeemedBy,omitempty"`   // Address of account redeemed the check
+}
+
+// Liability contains total amount of coins which can be still redeemed from issued checks.
+type Liability struct {
+	Coin        string  `json:"coin"`
+	Checks      int     `json:"checks"`      // Amount of outstanding checks
+	Outstanding sdk.Int `json:"outstanding"` // Total amount of outstanding checks in pip
+	Balance     sdk.Int `json:"balance"`     // Current issuer balance in pip
+}
+
+// Covered returns true if issuer balance is enough to redeem all outstanding checks.
+func (l *Liability) Covered() bool {
+	return l.Balance.GTE(l.Outstanding)
+}
+
+// Shortfall returns amount of coins in pip missing on issuer balance to redeem all outstanding checks.
+func (l *Liability) Shortfall() sdk.Int {
+	if l.Covered() {
+		return sdk.ZeroInt()
+	}
+	return l.Outstanding.Sub(l.Balance)
+}
+
+// PassphraseFunc returns passphrase for the check with specified index in the batch.
+type PassphraseFunc func(index int) (string, error)
+
+// SharedPassphrase returns PassphraseFunc using the same passphrase for each check in the batch.
+func SharedPassphrase(passphrase string) PassphraseFunc {
+	return func(int) (string, error) {
+		return passphrase, nil
+	}
+}
+
+// ListPassphrases returns PassphraseFunc using passphrases from the list in order.
+func ListPassphrases(passphrases []string) PassphraseFunc {
+	return func(index int) (string, error) {
+		if index >= len(passphrases) {
+			return "", fmt.Errorf("passphrase for check #%d is not specified", index)
+		}
+		return passphrases[index], nil
+	}
+}
+
+// RandomPassphrases returns PassphraseFunc generating random (crypto safe) passphrase
+// from specified amount of random bytes encoded to base58 format for each check in the batch.
+func RandomPassphrases(size int) PassphraseFunc {
+	return func(int) (string, error) {
+		buf := make([]byte, size)
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		return base58.Encode(buf), nil
+	}
+}
+
+// CheckBook issues checks in batches and tracks their state in the blockchain.
+type CheckBook struct {
+	api     *decapi.API
+	account *wallet.Account
+	store   Store
+
+	checks []*IssuedCheck
+	nonces map[string]bool
+	mtx    sync.Mutex
+}
+
+// New creates check book for checks issued by specified account and loads previously issued checks from the store.
+// NOTE: Account must have chain ID set up.
+func New(api *decapi.API, account *wallet.Account, store Store) (*CheckBook, error) {
+	checks, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	nonces := make(map[string]bool, len(checks))
+	for _, check := range checks {
+		nonces[check.Nonce] = true
+	}
+	return &CheckBook{
+		api:     api,
+		account: account,
+		store:   store,
+		checks:  checks,
+		nonces:  nonces,
+	}, nil
+}
+
+// Checks returns all checks issued by the check book.
+func (cb *CheckBook) Checks() []*IssuedCheck {
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+
+	result := make([]*IssuedCheck, len(cb.checks))
+	copy(result, cb.checks)
+	return result
+}
+
+// IssueBatch issues specified amount of checks each containing the same amount of coins.
+// Each check gets unique random nonce and passphrase returned by passphraseFunc.
+// Issued checks are saved to the store before returning, so they can be handed out safely.
+// Passphrases are returned only in the result and never persisted.
+func (cb *CheckBook) IssueBatch(coin string, amount sdk.Int, count int, dueBlock uint64, passphraseFunc PassphraseFunc) ([]*IssuedCheck, error) {
+	if count <= 0 {
+		return nil, errors.New("amount of checks must be positive")
+	}
+	coin = strings.ToLower(coin)
+
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+
+	batch := make([]*IssuedCheck, 0, count)
+	batchNonces := make(map[string]bool, count)
+	for i := 0; i < count; i++ {
+		passphrase, err := passphraseFunc(i)
+		if err != nil {
+			return nil, err
+		}
+		nonce, err := cb.newUniqueNonce(batchNonces)
+		if err != nil {
+			return nil, err
+		}
+		check, err := cb.account.IssueCheck(coin, amount, nonce, dueBlock, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("unable to issue check #%d: %w", i, err)
+		}
+		passphraseHash := sha256.Sum256([]byte(passphrase))
+		batch = append(batch, &IssuedCheck{
+			Nonce:          nonce.String(),
+			Coin:           coin,
+			Amount:         amount.String(),
+			DueBlock:       dueBlock,
+			Check:          check,
+			PassphraseHash: hex.EncodeToString(passphraseHash[:]),
+			Passphrase:     passphrase,
+			Status:         StatusIssued,
+			IssuedAt:       time.Now().UTC(),
+		})
+		batchNonces[nonce.String()] = true
+	}
+
+	// Persist batch before handing out the checks
+	if err := cb.store.Save(append(cb.checks[:len(cb.checks):len(cb.checks)], batch...)); err != nil {
+		return nil, err
+	}
+	cb.checks = append(cb.checks, batch...)
+	for nonce := range batchNonces {
+		cb.nonces[nonce] = true
+	}
+	return batch, nil
+}
+
+// newUniqueNonce generates random nonce not used by any check issued by the check book.
+func (cb *CheckBook) newUniqueNonce(batchNonces map[string]bool) (sdk.Int, error) {
+	for {
+		nonce, err := wallet.NewCheckNonce()
+		if err != nil {
+			return sdk.Int{}, err
+		}
+		if !cb.nonces[nonce.String()] && !batchNonces[nonce.String()] {
+			return nonce, nil
+		}
+	}
+}
+
+// Sync marks checks redeemed or expired by scanning redeem check transactions in the blockchain
+// and comparing due blocks with current height. Updated state is saved to the store.
+func (cb *CheckBook) Sync() error {
+	redeemed, err := cb.api.RedeemedChecks(cb.account.Address())
+	if err != nil {
+		return err
+	}
+	height, err := cb.api.GetHeight()
+	if err != nil {
+		return err
+	}
+
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+
+	redeemedByNonce := make(map[string]*decapi.RedeemedCheckResult, len(redeemed))
+	for _, r := range redeemed {
+		redeemedByNonce[r.Nonce] = r
+	}
+	for _, check := range cb.checks {
+		if check.Status == StatusRedeemed {
+			continue
+		}
+		if r, ok := redeemedByNonce[check.Nonce]; ok {
+			check.Status = StatusRedeemed
+			check.RedeemTxHash = r.TxHash
+			check.RedeemedBy = r.Sender
+			continue
+		}
+		if check.DueBlock < height {
+			check.Status = StatusExpired
+		}
+	}
+	return cb.store.Save(cb.checks)
+}
+
+// Liability returns total amount of outstanding (issued but not redeemed or expired) checks
+// per coin together with current issuer balance.
+// NOTE: Call Sync first to get actual state of the checks.
+func (cb *CheckBook) Liability() ([]*Liability, error) {
+	address, err := cb.api.Address(cb.account.Address())
+	if err != nil {
+		return nil, err
+	}
+
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+
+	liabilities := make(map[string]*Liability)
+	for _, check := range cb.checks {
+		if check.Status != StatusIssued {
+			continue
+		}
+		amount, err := decapi.ParseAmount(check.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount of check with nonce %s: %w", check.Nonce, err)
+		}
+		l, ok := liabilities[check.Coin]
+		if !ok {
+			l = &Liability{Coin: check.Coin, Outstanding: sdk.ZeroInt(), Balance: sdk.ZeroInt()}
+			balance, err := address.BalanceOf(check.Coin)
+			if err != nil {
+				return nil, err
+			}
+			l.Balance = balance.Pip()
+			liabilities[check.Coin] = l
+		}
+		l.Checks++
+		l.Outstanding = l.Outstanding.Add(amount.Pip())
+	}
+
+	result := make([]*Liability, 0, len(liabilities))
+	for _, l := range liabilities {
+		result = append(result, l)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Coin < result[j].Coin })
+	return result, nil
+}
@@ -0,0 +1,74 @@
+package checkbook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store persists issued checks between check book sessions.
+type Store interface {
+	// Load returns all checks saved in the store.
+	Load() ([]*IssuedCheck, error)
+	// Save replaces content of the store with specified checks.
+	Save(checks []*IssuedCheck) error
+}
+
+// FileStore is a Store keeping issued checks in local JSON file.
+type FileStore struct {
+	path string
+	mtx  sync.Mutex
+}
+
+// NewFileStore creates file store using file at specified path.
+// The file is created on first save if it does not exist.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Load returns all checks saved in the file.
+func (s *FileStore) Load() ([]*IssuedCheck, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	data, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return []*IssuedCheck{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	checks := []*IssuedCheck{}
+	if err = json.Unmarshal(data, &checks); err != nil {
+		return nil, err
+	}
+	return checks, nil
+}
+
+// Save writes specified checks to the file.
+// Data is written to temporary file first and then renamed so the file is never left half-written.
+func (s *FileStore) Save(checks []*IssuedCheck) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	data, err := json.MarshalIndent(checks, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	decapi "bitbucket.org/decimalteam/decimal-go-sdk/api"
+	"bitbucket.org/decimalteam/decimal-go-sdk/wallet"
+)
+
+// checkInfo contains decoded check fields.
+type checkInfo struct {
+	ChainID  string `json:"chainId"`
+	Issuer   string `json:"issuer"`
+	Coin     string `json:"coin"`
+	Amount   string `json:"amount"` // Amount of coins in pip
+	Nonce    string `json:"nonce"`
+	DueBlock uint64 `json:"dueBlock"`
+}
+
+func runCheck(ctx *session, args []string) error {
+	name, args, err := subcommand(args, "issue", "redeem", "inspect")
+	if err != nil {
+		return err
+	}
+	switch name {
+	case "issue":
+		return runCheckIssue(ctx, args)
+	case "redeem":
+		return runCheckRedeem(ctx, args)
+	default:
+		return runCheckInspect(ctx, args)
+	}
+}
+
+func runCheckIssue(ctx *session, args []string) error {
+	fs := flag.NewFlagSet("check issue", flag.ContinueOnError)
+	key := fs.String("key", "", "name of the key in the keystore issuing the check")
+	passphrase := fs.String("passphrase", "", "passphrase required to redeem the check")
+	dueBlock := fs.Uint64("due-block", 0, "number of block until which the check is valid")
+	ttl := fs.Uint64("ttl", 0, "amount of blocks from current height during which the check is valid (instead of -due-block)")
+	if err := parseFlags(fs, args, 1, 1); err != nil {
+		return fmt.Errorf("%w, usage: check issue -key <name> -passphrase <passphrase> -due-block <block> <amount><coin>", err)
+	}
+	if *key == "" || *passphrase == "" {
+		return fmt.Errorf("key and passphrase are required, use -key and -passphrase flags")
+	}
+	coin, err := parseCoin(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if *dueBlock == 0 {
+		if *ttl == 0 {
+			return fmt.Errorf("due block is required, use -due-block or -ttl flag")
+		}
+		height, err := ctx.api.GetHeight()
+		if err != nil {
+			return err
+		}
+		*dueBlock = height + *ttl
+	}
+	account, err := ctx.keystore.account(*key)
+	if err != nil {
+		return err
+	}
+	chainID, err := ctx.api.ChainID()
+	if err != nil {
+		return err
+	}
+	nonce, err := wallet.NewCheckNonce()
+	if err != nil {
+		return err
+	}
+	check, err := account.WithChainID(chainID).IssueCheck(coin.Denom, coin.Amount, nonce, *dueBlock, *passphrase)
+	if err != nil {
+		return err
+	}
+	return ctx.print(
+		map[string]interface{}{"check": check, "nonce": nonce.String(), "dueBlock": *dueBlock},
+		newTable("CHECK", "NONCE", "DUE BLOCK").add(check, nonce, *dueBlock),
+	)
+}
+
+func runCheckRedeem(ctx *session, args []string) error {
+	fs := flag.NewFlagSet("check redeem", flag.ContinueOnError)
+	flags := addTxFlags(fs)
+	passphrase := fs.String("passphrase", "", "passphrase the check was issued with")
+	if err := parseFlags(fs, args, 1, 1); err != nil {
+		return fmt.Errorf("%w, usage: check redeem -key <name> -passphrase <passphrase> <check>", err)
+	}
+	sender, err := ctx.keyAddress(*flags.key)
+	if err != nil {
+		return err
+	}
+	proof, err := wallet.NewCheckRedeemProof(sender, *passphrase)
+	if err != nil {
+		return err
+	}
+	return ctx.sendTx(flags, decapi.NewMsgRedeemCheck(sender, fs.Arg(0), proof))
+}
+
+func runCheckInspect(ctx *session, args []string) error {
+	fs := flag.NewFlagSet("check inspect", flag.ContinueOnError)
+	if err := parseFlags(fs, args, 1, 1); err != nil {
+		return fmt.Errorf("%w, usage: check inspect <check>", err)
+	}
+	check, err := wallet.ParseCheck(base58.Decode(fs.Arg(0)))
+	if err != nil {
+		return fmt.Errorf("unable to parse check: %w", err)
+	}
+	issuer, err := check.Sender()
+	if err != nil {
+		return err
+	}
+	info := checkInfo{
+		ChainID:  check.ChainID,
+		Issuer:   issuer.String(),
+		Coin:     strings.ToLower(check.Coin),
+		Amount:   sdk.NewIntFromBigInt(check.Amount).String(),
+		Nonce:    new(big.Int).SetBytes(check.Nonce).String(),
+		DueBlock: check.DueBlock,
+	}
+	t := newTable("CHAIN ID", "ISSUER", "AMOUNT", "NONCE", "DUE BLOCK")
+	t.add(info.ChainID, info.Issuer, displayAmount(info.Amount)+" "+info.Coin, info.Nonce, info.DueBlock)
+	return ctx.print(info, t)
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"bitbucket.org/decimalteam/decimal-go-sdk/wallet"
+)
+
+// mnemonicBits is entropy size of generated mnemonics (24 words).
+const mnemonicBits = 256
+
+func runKeys(ctx *session, args []string) error {
+	name, args, err := subcommand(args, "add", "list", "export")
+	if err != nil {
+		return err
+	}
+	switch name {
+	case "add":
+		return runKeysAdd(ctx, args)
+	case "list":
+		return runKeysList(ctx, args)
+	default:
+		return runKeysExport(ctx, args)
+	}
+}
+
+// runKeysAdd generates new key or recovers it from mnemonic read from stdin.
+func runKeysAdd(ctx *session, args []string) error {
+	fs := flag.NewFlagSet("keys add", flag.ContinueOnError)
+	recoverKey := fs.Bool("recover", false, "recover key from mnemonic read from stdin instead of generating new one")
+	bip39Passphrase := fs.String("bip39-passphrase", "", "optional BIP39 passphrase of the mnemonic")
+	if err := parseFlags(fs, args, 1, 1); err != nil {
+		return fmt.Errorf("%w, usage: keys add [-recover] <name>", err)
+	}
+
+	secret := keySecret{Passphrase: *bip39Passphrase}
+	if *recoverKey {
+		fmt.Fprint(os.Stderr, "Enter mnemonic: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return err
+		}
+		secret.Mnemonic = strings.Join(strings.Fields(line), " ")
+	} else {
+		mnemonic, err := wallet.NewMnemonic(mnemonicBits, secret.Passphrase)
+		if err != nil {
+			return err
+		}
+		secret.Mnemonic = mnemonic.Words()
+	}
+
+	password, err := readPassword("New password: ")
+	if err != nil {
+		return err
+	}
+	if password == "" {
+		return errors.New("password must not be empty")
+	}
+	account, err := ctx.keystore.add(fs.Arg(0), secret, password)
+	if err != nil {
+		return err
+	}
+	if !*recoverKey {
+		fmt.Fprintf(os.Stderr, "Write down the mnemonic, it is the only way to recover the key:\n%s\n\n", secret.Mnemonic)
+	}
+	return ctx.print(
+		map[string]string{"name": fs.Arg(0), "address": account.Address()},
+		newTable("NAME", "ADDRESS").add(fs.Arg(0), account.Address()),
+	)
+}
+
+func runKeysList(ctx *session, args []string) error {
+	fs := flag.NewFlagSet("keys list", flag.ContinueOnError)
+	if err := parseFlags(fs, args, 0, 0); err != nil {
+		return fmt.Errorf("%w, usage: keys list", err)
+	}
+	keys, err := ctx.keystore.list()
+	if err != nil {
+		return err
+	}
+	result := make([]map[string]string, 0, len(keys))
+	t := newTable("NAME", "ADDRESS")
+	for _, key := range keys {
+		result = append(result, map[string]string{"name": key.Name, "address": key.Address})
+		t.add(key.Name, key.Address)
+	}
+	return ctx.print(result, t)
+}
+
+// runKeysExport decrypts the key and prints its mnemonic.
+func runKeysExport(ctx *session, args []string) error {
+	fs := flag.NewFlagSet("keys export", flag.ContinueOnError)
+	if err := parseFlags(fs, args, 1, 1); err != nil {
+		return fmt.Errorf("%w, usage: keys export <name>", err)
+	}
+	password, err := readPassword(fmt.Sprintf("Password of key %q: ", fs.Arg(0)))
+	if err != nil {
+		return err
+	}
+	secret, err := ctx.keystore.secret(fs.Arg(0), password)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "WARNING: anyone knowing the mnemonic has full control over the account.")
+	return ctx.print(secret, newTable().add(secret.Mnemonic))
+}
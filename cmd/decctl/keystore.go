@@ -0,0 +1,214 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"bitbucket.org/decimalteam/decimal-go-sdk/wallet"
+)
+
+// passwordEnv is environment variable containing keystore password for non-interactive usage.
+const passwordEnv = "DECCTL_PASSWORD"
+
+// Parameters of scrypt key derivation used to encrypt keys.
+const (
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+)
+
+// keystore keeps mnemonics encrypted with password in the directory, one file per key.
+type keystore struct {
+	dir string
+}
+
+// keyFile is a content of the key file.
+type keyFile struct {
+	Name       string `json:"name"`
+	Address    string `json:"address"`
+	Salt       string `json:"salt"`  // Salt of scrypt key derivation in hex format
+	Nonce      string `json:"nonce"` // Nonce of secretbox in hex format
+	Ciphertext string `json:"ciphertext"`
+	N          int    `json:"n"`
+	R          int    `json:"r"`
+	P          int    `json:"p"`
+}
+
+// keySecret is encrypted part of the key file.
+type keySecret struct {
+	Mnemonic   string `json:"mnemonic"`
+	Passphrase string `json:"passphrase"` // Optional BIP39 passphrase
+}
+
+func newKeystore(dir string) *keystore {
+	return &keystore{dir: dir}
+}
+
+func (ks *keystore) path(name string) string {
+	return filepath.Join(ks.dir, name+".json")
+}
+
+// add encrypts mnemonic with password and saves it to the keystore.
+func (ks *keystore) add(name string, secret keySecret, password string) (*wallet.Account, error) {
+	if name == "" || strings.ContainsAny(name, `/\.`) {
+		return nil, fmt.Errorf("invalid key name %q", name)
+	}
+	if _, err := os.Stat(ks.path(name)); err == nil {
+		return nil, fmt.Errorf("key %q already exists", name)
+	}
+	account, err := wallet.NewAccountFromMnemonicWords(secret.Mnemonic, secret.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	kf := keyFile{Name: name, Address: account.Address(), N: scryptN, R: scryptR, P: scryptP}
+	salt := make([]byte, 32)
+	var nonce [24]byte
+	if _, err = rand.Read(salt); err != nil {
+		return nil, err
+	}
+	if _, err = rand.Read(nonce[:]); err != nil {
+		return nil, err
+	}
+	key, err := deriveKey(password, salt, kf.N, kf.R, kf.P)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := json.Marshal(secret)
+	if err != nil {
+		return nil, err
+	}
+	kf.Salt = hex.EncodeToString(salt)
+	kf.Nonce = hex.EncodeToString(nonce[:])
+	kf.Ciphertext = hex.EncodeToString(secretbox.Seal(nil, plaintext, &nonce, key))
+
+	data, err := json.MarshalIndent(kf, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(ks.dir, 0700); err != nil {
+		return nil, err
+	}
+	if err = ioutil.WriteFile(ks.path(name), data, 0600); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// list returns all keys in the keystore without decrypting them.
+func (ks *keystore) list() ([]*keyFile, error) {
+	files, err := filepath.Glob(filepath.Join(ks.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	keys := make([]*keyFile, 0, len(files))
+	for _, file := range files {
+		kf, err := readKeyFile(file)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, kf)
+	}
+	return keys, nil
+}
+
+// secret decrypts the key with specified name.
+func (ks *keystore) secret(name string, password string) (*keySecret, error) {
+	kf, err := readKeyFile(ks.path(name))
+	if err != nil {
+		return nil, err
+	}
+	salt, err := hex.DecodeString(kf.Salt)
+	if err != nil {
+		return nil, err
+	}
+	nonceBytes, err := hex.DecodeString(kf.Nonce)
+	if err != nil || len(nonceBytes) != 24 {
+		return nil, errors.New("invalid nonce in the key file")
+	}
+	ciphertext, err := hex.DecodeString(kf.Ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	key, err := deriveKey(password, salt, kf.N, kf.R, kf.P)
+	if err != nil {
+		return nil, err
+	}
+	var nonce [24]byte
+	copy(nonce[:], nonceBytes)
+	plaintext, ok := secretbox.Open(nil, ciphertext, &nonce, key)
+	if !ok {
+		return nil, errors.New("invalid password")
+	}
+	secret := &keySecret{}
+	if err = json.Unmarshal(plaintext, secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// account decrypts the key with specified name and creates account from it.
+func (ks *keystore) account(name string) (*wallet.Account, error) {
+	password, err := readPassword(fmt.Sprintf("Password of key %q: ", name))
+	if err != nil {
+		return nil, err
+	}
+	secret, err := ks.secret(name, password)
+	if err != nil {
+		return nil, err
+	}
+	return wallet.NewAccountFromMnemonicWords(secret.Mnemonic, secret.Passphrase)
+}
+
+func readKeyFile(path string) (*keyFile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("key %q not found", strings.TrimSuffix(filepath.Base(path), ".json"))
+		}
+		return nil, err
+	}
+	kf := &keyFile{}
+	if err = json.Unmarshal(data, kf); err != nil {
+		return nil, fmt.Errorf("invalid key file %s: %w", path, err)
+	}
+	return kf, nil
+}
+
+func deriveKey(password string, salt []byte, n, r, p int) (*[32]byte, error) {
+	derived, err := scrypt.Key([]byte(password), salt, n, r, p, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+	var key [32]byte
+	copy(key[:], derived)
+	return &key, nil
+}
+
+// readPassword reads password from environment variable or from the terminal.
+func readPassword(prompt string) (string, error) {
+	if password, ok := os.LookupEnv(passwordEnv); ok {
+		return password, nil
+	}
+	if !terminal.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("password is required, set it with %s environment variable", passwordEnv)
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	password, err := terminal.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	return string(password), err
+}
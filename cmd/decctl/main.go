@@ -0,0 +1,146 @@
+// Command decctl is a command-line tool to query Decimal blockchain and send transactions.
+//
+// Usage:
+//
+//	decctl [global flags] <command> [subcommand] [flags] [arguments]
+//
+// Run `decctl help` to see list of commands.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	decapi "bitbucket.org/decimalteam/decimal-go-sdk/api"
+)
+
+// command is a function implementing decctl command.
+type command struct {
+	usage string
+	run   func(ctx *session, args []string) error
+}
+
+// commands contains all commands supported by decctl.
+var commands = map[string]command{
+	"address":      {"address <address>                                  show account balances", runAddress},
+	"coin":         {"coin [symbol]                                      show coin or list of all coins", runCoin},
+	"validators":   {"validators [address]                               show validator or list of all validators", runValidators},
+	"tx":           {"tx get|send|multisend|broadcast ...                query or send transactions", runTx},
+	"check":        {"check issue|redeem|inspect ...                     issue, redeem or inspect checks", runCheck},
+	"nft":          {"nft [id]                                           show NFT or list of all NFTs", runNFT},
+	"gov":          {"gov vote <proposal-id> <yes|no|abstain>            vote for proposal as validator", runGov},
+	"keys":         {"keys add|list|export ...                           manage keys in the keystore", runKeys},
+	"sign-offline": {"sign-offline -key <name> <unsigned> <signed>       sign transaction envelope offline", runSignOffline},
+}
+
+// session contains global options shared by all commands.
+type session struct {
+	network  decapi.Network
+	output   string
+	keystore *keystore
+
+	api *decapi.API
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	home, _ := os.UserHomeDir()
+
+	fs := flag.NewFlagSet("decctl", flag.ContinueOnError)
+	fs.Usage = func() { printUsage(fs) }
+	networkName := fs.String("network", decapi.TestNet.Name, "network profile (mainnet, testnet, devnet)")
+	node := fs.String("node", "", "host of the node for direct connection instead of the gateway (e.g. http://localhost)")
+	portREST := fs.String("rest-port", "", "REST port of the node for direct connection (default :1317)")
+	portRPC := fs.String("rpc-port", "", "RPC port of the node for direct connection (default :26657)")
+	output := fs.String("output", outputTable, "output format (table, json)")
+	keystoreDir := fs.String("keystore", filepath.Join(home, ".decctl", "keys"), "directory of the keystore")
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() == 0 || fs.Arg(0) == "help" {
+		printUsage(fs)
+		return nil
+	}
+
+	network, ok := decapi.Networks[*networkName]
+	if !ok {
+		return fmt.Errorf("unknown network %q", *networkName)
+	}
+	if *node != "" {
+		network = network.WithDirectConn(*node, &decapi.DirectConn{PortREST: *portREST, PortRPC: *portRPC})
+	}
+	if *output != outputTable && *output != outputJSON {
+		return fmt.Errorf("unknown output format %q", *output)
+	}
+
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		return fmt.Errorf("unknown command %q, run `decctl help` to see list of commands", fs.Arg(0))
+	}
+	api, err := decapi.NewAPIForNetwork(network)
+	if err != nil {
+		return err
+	}
+	ctx := &session{
+		network:  network,
+		output:   *output,
+		keystore: newKeystore(*keystoreDir),
+		api:      api,
+	}
+	defer ctx.api.Close()
+	return cmd.run(ctx, fs.Args()[1:])
+}
+
+func printUsage(fs *flag.FlagSet) {
+	out := fs.Output()
+	fmt.Fprintln(out, "Usage: decctl [global flags] <command> [subcommand] [flags] [arguments]")
+	fmt.Fprintln(out, "\nCommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(out, "  %s\n", commands[name].usage)
+	}
+	fmt.Fprintln(out, "\nGlobal flags:")
+	fs.PrintDefaults()
+}
+
+// subcommand returns name of the subcommand and its arguments.
+func subcommand(args []string, names ...string) (string, []string, error) {
+	if len(args) == 0 {
+		return "", nil, fmt.Errorf("subcommand is required: %s", strings.Join(names, ", "))
+	}
+	for _, name := range names {
+		if args[0] == name {
+			return name, args[1:], nil
+		}
+	}
+	return "", nil, fmt.Errorf("unknown subcommand %q, expected one of: %s", args[0], strings.Join(names, ", "))
+}
+
+// parseFlags parses flags of the command and checks amount of positional arguments.
+func parseFlags(fs *flag.FlagSet, args []string, minArgs, maxArgs int) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < minArgs || (maxArgs >= 0 && fs.NArg() > maxArgs) {
+		return errors.New("wrong amount of arguments")
+	}
+	return nil
+}
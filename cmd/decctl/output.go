@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// Output formats.
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// table contains result presented as rows of cells.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// newTable creates table with specified column names.
+func newTable(header ...string) *table {
+	return &table{header: header}
+}
+
+// add appends row to the table, values are formatted with %v.
+func (t *table) add(values ...interface{}) *table {
+	row := make([]string, len(values))
+	for i, v := range values {
+		row[i] = fmt.Sprintf("%v", v)
+	}
+	t.rows = append(t.rows, row)
+	return t
+}
+
+// print prints result in format chosen by global flag: as JSON or as table.
+func (ctx *session) print(result interface{}, t *table) error {
+	if ctx.output == outputJSON {
+		return printJSON(result)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if len(t.header) > 0 {
+		fmt.Fprintln(w, strings.Join(t.header, "\t"))
+	}
+	for _, row := range t.rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// printJSON prints value in indented JSON format.
+func printJSON(v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
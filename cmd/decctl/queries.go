@@ -0,0 +1,120 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+
+	decapi "bitbucket.org/decimalteam/decimal-go-sdk/api"
+)
+
+// displayAmount formats amount in pip as amount in whole coins.
+func displayAmount(value string) string {
+	amount, err := decapi.ParseAmount(value)
+	if err != nil {
+		return value
+	}
+	return amount.Display().String()
+}
+
+func runAddress(ctx *session, args []string) error {
+	fs := flag.NewFlagSet("address", flag.ContinueOnError)
+	if err := parseFlags(fs, args, 1, 1); err != nil {
+		return fmt.Errorf("%w, usage: address <address>", err)
+	}
+	address, err := ctx.api.Address(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	symbols := make([]string, 0, len(address.Balance))
+	for symbol := range address.Balance {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	t := newTable("COIN", "BALANCE")
+	for _, symbol := range symbols {
+		t.add(symbol, displayAmount(address.Balance[symbol]))
+	}
+	return ctx.print(address, t)
+}
+
+func runCoin(ctx *session, args []string) error {
+	fs := flag.NewFlagSet("coin", flag.ContinueOnError)
+	if err := parseFlags(fs, args, 0, 1); err != nil {
+		return fmt.Errorf("%w, usage: coin [symbol]", err)
+	}
+	var coins []*decapi.CoinResult
+	if fs.NArg() == 1 {
+		coin, err := ctx.api.Coin(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		coins = []*decapi.CoinResult{coin}
+	} else {
+		var err error
+		if coins, err = ctx.api.Coins(); err != nil {
+			return err
+		}
+	}
+	t := newTable("SYMBOL", "TITLE", "CRR", "RESERVE", "VOLUME", "LIMIT VOLUME", "CREATOR")
+	for _, c := range coins {
+		t.add(c.Symbol, c.Title, c.Crr, displayAmount(c.Reserve), displayAmount(c.Volume), displayAmount(c.LimitVolume), c.Creator)
+	}
+	if fs.NArg() == 1 {
+		return ctx.print(coins[0], t)
+	}
+	return ctx.print(coins, t)
+}
+
+func runValidators(ctx *session, args []string) error {
+	fs := flag.NewFlagSet("validators", flag.ContinueOnError)
+	if err := parseFlags(fs, args, 0, 1); err != nil {
+		return fmt.Errorf("%w, usage: validators [address]", err)
+	}
+	var validators []*decapi.ValidatorResult
+	if fs.NArg() == 1 {
+		validator, err := ctx.api.Validator(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		validators = []*decapi.ValidatorResult{validator}
+	} else {
+		var err error
+		if validators, err = ctx.api.Validators(); err != nil {
+			return err
+		}
+	}
+	t := newTable("ADDRESS", "MONIKER", "STATUS", "STAKE", "FEE")
+	for _, v := range validators {
+		t.add(v.Address, v.Moniker, v.Status, displayAmount(v.Stake), v.Comission)
+	}
+	if fs.NArg() == 1 {
+		return ctx.print(validators[0], t)
+	}
+	return ctx.print(validators, t)
+}
+
+func runNFT(ctx *session, args []string) error {
+	fs := flag.NewFlagSet("nft", flag.ContinueOnError)
+	if err := parseFlags(fs, args, 0, 1); err != nil {
+		return fmt.Errorf("%w, usage: nft [id]", err)
+	}
+	if fs.NArg() == 1 {
+		nft, err := ctx.api.NFT(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		t := newTable("ID", "COLLECTION", "QUANTITY", "TOTAL RESERVE", "TOKEN URI")
+		t.add(nft.Id, nft.CollectionName, nft.Quantity, displayAmount(nft.TotalReserve), nft.TokenURI)
+		return ctx.print(nft, t)
+	}
+	nfts, err := ctx.api.NFTList()
+	if err != nil {
+		return err
+	}
+	t := newTable("ID", "COLLECTION", "CREATOR", "QUANTITY", "DELEGATED")
+	for _, nft := range nfts {
+		t.add(nft.Id, nft.CollectionName, nft.Creator, nft.Quantity, nft.Delegated)
+	}
+	return ctx.print(nfts, t)
+}
@@ -0,0 +1,317 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	decapi "bitbucket.org/decimalteam/decimal-go-sdk/api"
+	"bitbucket.org/decimalteam/decimal-go-sdk/wallet"
+)
+
+// txFlags contains flags common for all commands sending transactions.
+type txFlags struct {
+	key      *string
+	memo     *string
+	feeCoin  *string
+	unsigned *string
+}
+
+func addTxFlags(fs *flag.FlagSet) *txFlags {
+	return &txFlags{
+		key:      fs.String("key", "", "name of the key in the keystore signing the transaction"),
+		memo:     fs.String("memo", "", "transaction memo"),
+		feeCoin:  fs.String("fee-coin", "", "coin to pay the fee (default base coin)"),
+		unsigned: fs.String("unsigned", "", "write unsigned transaction envelope to the file instead of signing and broadcasting"),
+	}
+}
+
+// parseCoin parses amount of coins in whole coins with symbol, e.g. "1.5del".
+func parseCoin(value string) (sdk.Coin, error) {
+	coin, err := sdk.ParseDecCoin(strings.ToLower(value))
+	if err != nil {
+		return sdk.Coin{}, fmt.Errorf("invalid amount %q, expected amount with coin symbol like 1.5del", value)
+	}
+	return sdk.NewCoin(coin.Denom, decapi.CoinToPip(coin.Amount)), nil
+}
+
+// keyAddress returns address of the key from the keystore without decrypting it.
+func (ctx *session) keyAddress(name string) (sdk.AccAddress, error) {
+	if name == "" {
+		return nil, fmt.Errorf("key is required, use -key flag")
+	}
+	kf, err := readKeyFile(ctx.keystore.path(name))
+	if err != nil {
+		return nil, err
+	}
+	return sdk.AccAddressFromBech32(kf.Address)
+}
+
+// boundAccount decrypts the key and binds it with chain ID, account number and sequence from the network.
+func (ctx *session) boundAccount(name string) (*wallet.Account, error) {
+	account, err := ctx.keystore.account(name)
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := ctx.api.ChainID()
+	if err != nil {
+		return nil, err
+	}
+	an, s, err := ctx.api.AccountNumberAndSequence(account.Address())
+	if err != nil {
+		return nil, err
+	}
+	return account.WithChainID(chainID).WithAccountNumber(an).WithSequence(s), nil
+}
+
+// sendTx signs and broadcasts transaction containing the messages
+// or writes unsigned transaction envelope to the file if requested.
+func (ctx *session) sendTx(flags *txFlags, msgs ...sdk.Msg) error {
+	for _, msg := range msgs {
+		if err := msg.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	feeCoins, err := ctx.feeCoins(strings.ToLower(*flags.feeCoin), *flags.memo, msgs)
+	if err != nil {
+		return err
+	}
+
+	if *flags.unsigned != "" {
+		signer, err := ctx.keyAddress(*flags.key)
+		if err != nil {
+			return err
+		}
+		env, err := ctx.api.NewTxEnvelope(msgs, feeCoins, *flags.memo, signer.String())
+		if err != nil {
+			return err
+		}
+		return ctx.writeEnvelope(*flags.unsigned, env)
+	}
+
+	if *flags.key == "" {
+		return fmt.Errorf("key is required, use -key flag")
+	}
+	account, err := ctx.boundAccount(*flags.key)
+	if err != nil {
+		return err
+	}
+	tx, err := ctx.api.NewSignedTransaction(msgs, feeCoins, *flags.memo, account)
+	if err != nil {
+		return err
+	}
+	result, err := ctx.api.BroadcastSignedTransactionJSON(tx, account)
+	if err != nil {
+		return err
+	}
+	return ctx.printBroadcastResult(result)
+}
+
+// feeCoins returns fee of the transaction with the messages in the fee coin. Fee in base coin is deducted
+// by the node, so it is not set. Fee in custom coin takes bytes of the transaction, so it is increased
+// until it covers the transaction.
+func (ctx *session) feeCoins(feeCoin, memo string, msgs []sdk.Msg) (sdk.Coins, error) {
+	const maxFeeIterations = 8
+	baseCoin, err := ctx.api.BaseCoin()
+	if err != nil {
+		return nil, err
+	}
+	if feeCoin == "" || feeCoin == baseCoin {
+		return nil, nil
+	}
+	fee := sdk.NewCoin(feeCoin, sdk.OneInt())
+	for i := 0; i < maxFeeIterations; i++ {
+		tx := auth.NewStdTx(msgs, auth.NewStdFee(0, sdk.NewCoins(fee)), nil, memo)
+		breakdown, err := ctx.api.FeeBreakdownInCoin(tx, feeCoin)
+		if err != nil {
+			return nil, fmt.Errorf("unable to compute fee in coin %q: %w", feeCoin, err)
+		}
+		if breakdown.TotalInCoin.Amount.LTE(fee.Amount) {
+			return sdk.NewCoins(fee), nil
+		}
+		fee = *breakdown.TotalInCoin
+	}
+	return nil, fmt.Errorf("unable to compute fee in coin %q", feeCoin)
+}
+
+func (ctx *session) printBroadcastResult(result *decapi.BroadcastTxResult) error {
+	return ctx.print(result, newTable("TX HASH", "HEIGHT", "CODE").add(result.TxHash, result.Height, result.Code))
+}
+
+func (ctx *session) readEnvelope(path string) (*decapi.TxEnvelope, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.api.DecodeTxEnvelope(data)
+}
+
+func (ctx *session) writeEnvelope(path string, env *decapi.TxEnvelope) error {
+	data, err := ctx.api.EncodeTxEnvelope(env)
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(path, data, 0600); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Transaction envelope is written to %s\n", path)
+	return nil
+}
+
+func runTx(ctx *session, args []string) error {
+	name, args, err := subcommand(args, "get", "send", "multisend", "broadcast")
+	if err != nil {
+		return err
+	}
+	switch name {
+	case "get":
+		return runTxGet(ctx, args)
+	case "send":
+		return runTxSend(ctx, args)
+	case "multisend":
+		return runTxMultiSend(ctx, args)
+	default:
+		return runTxBroadcast(ctx, args)
+	}
+}
+
+func runTxGet(ctx *session, args []string) error {
+	fs := flag.NewFlagSet("tx get", flag.ContinueOnError)
+	if err := parseFlags(fs, args, 1, 1); err != nil {
+		return fmt.Errorf("%w, usage: tx get <hash>", err)
+	}
+	tx, err := ctx.api.Transaction(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	t := newTable("HASH", "HEIGHT", "CODE", "GAS USED", "LOG")
+	if tx.TxResult != nil {
+		t.add(tx.Hash, tx.Height, tx.TxResult.Code, tx.TxResult.GasUsed, tx.TxResult.Log)
+	} else {
+		t.add(tx.Hash, tx.Height, "", "", "")
+	}
+	return ctx.print(tx, t)
+}
+
+func runTxSend(ctx *session, args []string) error {
+	fs := flag.NewFlagSet("tx send", flag.ContinueOnError)
+	flags := addTxFlags(fs)
+	if err := parseFlags(fs, args, 2, 2); err != nil {
+		return fmt.Errorf("%w, usage: tx send -key <name> <receiver> <amount><coin>", err)
+	}
+	sender, err := ctx.keyAddress(*flags.key)
+	if err != nil {
+		return err
+	}
+	receiver, err := sdk.AccAddressFromBech32(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	coin, err := parseCoin(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	return ctx.sendTx(flags, decapi.NewMsgSendCoin(sender, coin, receiver))
+}
+
+func runTxMultiSend(ctx *session, args []string) error {
+	fs := flag.NewFlagSet("tx multisend", flag.ContinueOnError)
+	flags := addTxFlags(fs)
+	if err := parseFlags(fs, args, 1, -1); err != nil {
+		return fmt.Errorf("%w, usage: tx multisend -key <name> <receiver>=<amount><coin> ...", err)
+	}
+	sender, err := ctx.keyAddress(*flags.key)
+	if err != nil {
+		return err
+	}
+	sends := make([]decapi.Send, 0, fs.NArg())
+	for _, arg := range fs.Args() {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid send %q, expected <receiver>=<amount><coin>", arg)
+		}
+		receiver, err := sdk.AccAddressFromBech32(parts[0])
+		if err != nil {
+			return err
+		}
+		coin, err := parseCoin(parts[1])
+		if err != nil {
+			return err
+		}
+		sends = append(sends, decapi.Send{Receiver: receiver, Coin: coin})
+	}
+	return ctx.sendTx(flags, decapi.NewMsgMultiSendCoin(sender, sends))
+}
+
+// runTxBroadcast broadcasts transaction from signed envelope.
+func runTxBroadcast(ctx *session, args []string) error {
+	fs := flag.NewFlagSet("tx broadcast", flag.ContinueOnError)
+	if err := parseFlags(fs, args, 1, 1); err != nil {
+		return fmt.Errorf("%w, usage: tx broadcast <signed-envelope-file>", err)
+	}
+	env, err := ctx.readEnvelope(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	result, err := ctx.api.BroadcastTxEnvelope(env)
+	if err != nil {
+		return err
+	}
+	return ctx.printBroadcastResult(result)
+}
+
+// runSignOffline signs transaction envelope without any network requests.
+func runSignOffline(ctx *session, args []string) error {
+	fs := flag.NewFlagSet("sign-offline", flag.ContinueOnError)
+	key := fs.String("key", "", "name of the key in the keystore signing the transaction")
+	if err := parseFlags(fs, args, 2, 2); err != nil {
+		return fmt.Errorf("%w, usage: sign-offline -key <name> <unsigned-envelope-file> <signed-envelope-file>", err)
+	}
+	if *key == "" {
+		return fmt.Errorf("key is required, use -key flag")
+	}
+	env, err := ctx.readEnvelope(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	account, err := ctx.keystore.account(*key)
+	if err != nil {
+		return err
+	}
+	signed, err := ctx.api.SignTxEnvelope(env, account)
+	if err != nil {
+		return err
+	}
+	return ctx.writeEnvelope(fs.Arg(1), signed)
+}
+
+// runGov votes for the proposal as validator operated by the key.
+func runGov(ctx *session, args []string) error {
+	_, args, err := subcommand(args, "vote")
+	if err != nil {
+		return err
+	}
+	fs := flag.NewFlagSet("gov vote", flag.ContinueOnError)
+	flags := addTxFlags(fs)
+	if err := parseFlags(fs, args, 2, 2); err != nil {
+		return fmt.Errorf("%w, usage: gov vote -key <name> <proposal-id> <yes|no|abstain>", err)
+	}
+	voter, err := ctx.keyAddress(*flags.key)
+	if err != nil {
+		return err
+	}
+	var proposalID uint64
+	if _, err = fmt.Sscan(fs.Arg(0), &proposalID); err != nil {
+		return fmt.Errorf("invalid proposal ID %q", fs.Arg(0))
+	}
+	option, err := decapi.ParseVoteOption(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	return ctx.sendTx(flags, decapi.NewMsgVote(sdk.ValAddress(voter), proposalID, option))
+}
@@ -108,19 +108,16 @@ func (l log2log) Debugf(format string, v ...interface{}) {
 // Decimal SDK example running
 ////////////////////////////////////////////////////////////////
 
-// possible api host+options combinations
+// possible api network profiles
 var apiEndpoints = []struct {
 	endpointId string
-	hostURL    string
-	dcOptions  *decapi.DirectConn
-	netId      string
-	baseCoin   string
+	network    decapi.Network
 }{
-	{"testnet-gate", "https://testnet-gate.decimalchain.com/api", nil, "testnet", "tdel"},
-	{"devnet-gate", "https://devnet-gate.decimalchain.com/api", nil, "devnet", "del"},
-	{"devnet-local", "http://localhost", &decapi.DirectConn{}, "devnet", "del"}, // direct: RPC(port 26657)+REST(port 1317)
-	{"testnet-local", "http://localhost",
-		&decapi.DirectConn{PortRPC: ":26658", PortREST: ":1318"}, "testnet", "tdel"}, // direct: RPC(port 26658)+REST(port 1317)
+	{"testnet-gate", decapi.TestNet},
+	{"devnet-gate", decapi.DevNet},
+	{"devnet-local", decapi.DevNet.WithDirectConn("http://localhost", &decapi.DirectConn{})}, // direct: RPC(port 26657)+REST(port 1317)
+	{"testnet-local", decapi.TestNet.WithDirectConn("http://localhost",
+		&decapi.DirectConn{PortRPC: ":26658", PortREST: ":1318"})}, // direct: RPC(port 26658)+REST(port 1317)
 }
 
 //helper function
@@ -168,16 +165,19 @@ func main() {
 	defer logfile.Close()
 	log.SetOutput(logfile)
 
-	log.Printf("START: test endpoint %s with options %#v", endpoint.hostURL, endpoint.dcOptions)
+	log.Printf("START: test endpoint %s with options %#v", endpoint.network.URL, endpoint.network.DirectConn)
 	// 1
 	// You can simply use
-	// api := decapi.NewAPI(endpoint.hostURL, endpoint.dcOptions)
+	// api, err := decapi.NewAPIForNetwork(endpoint.network)
 	if *doLogRequests {
 		client1 := resty.New().SetDebug(true).SetLogger(log2log{}).SetTimeout(time.Second * 20)
 		client2 := resty.New().SetDebug(true).SetLogger(log2log{}).SetTimeout(time.Second * 20)
-		api = decapi.NewAPIWithClient(endpoint.hostURL, client1, client2, endpoint.dcOptions)
+		api, err = decapi.NewAPIForNetworkWithClient(endpoint.network, client1, client2)
 	} else {
-		api = decapi.NewAPI(endpoint.hostURL, endpoint.dcOptions)
+		api, err = decapi.NewAPIForNetwork(endpoint.network)
+	}
+	if err != nil {
+		log.Fatal(err)
 	}
 	// 2
 	chainId := exampleApiBlockchainInfo(api)
@@ -190,7 +190,7 @@ func main() {
 		}
 		// 3
 		for i = 0; i < (len(wallets) - 1); i++ {
-			fillWallet(api, wallets[i].Address(), endpoint.netId)
+			fillWallet(api, wallets[i].Address(), endpoint.network.Name)
 		}
 		time.Sleep(time.Second * 10) //wait for transaction
 		// 4
@@ -221,7 +221,7 @@ func main() {
 			symbol string
 			valid  bool
 		}{
-			{endpoint.baseCoin, true},
+			{endpoint.network.BaseCoin, true},
 			{"0del", false},
 		}
 		for _, symbol := range coinSymbols {
@@ -354,7 +354,7 @@ func main() {
 	}
 	////////////////////
 	if *checkSend {
-		testSend(api, endpoint.baseCoin)
+		testSend(api, endpoint.network.BaseCoin)
 		//testInvalidSendCoin(api)
 		//testInvalidSendSignature(api)
 		//testGovProposal(api)
@@ -247,14 +247,16 @@ func testGovProposal(api *decapi.API) {
 		log.Printf("ERROR: AccAddressFromBech32 %s->%s", acc1.Address(), err.Error())
 	}
 
-	msg := decapi.MsgSubmitProposal{}
-	//enc := []byte(`{"content":{"title":"test title", "description":"test"}}`)
-	//json.Unmarshal(enc, &msg)
-	msg.Content.Title = "test title"
-	msg.Content.Description = "test"
-	msg.Proposer = sender
-	msg.VotingStartBlock = 30100
-	msg.VotingEndBlock = 30000
+	height, err := api.GetHeight()
+	if err != nil {
+		log.Printf("ERROR: GetHeight %s", err.Error())
+		return
+	}
+	msg, err := api.NewTextProposal("test title", "test").VotingBlocks(height+100, height+1000).Msg(sender)
+	if err != nil {
+		log.Printf("ERROR: proposal %s", err.Error())
+		return
+	}
 	msgs := []sdk.Msg{msg}
 	feeCoins := sdk.NewCoins(sdk.NewCoin("del", sdk.NewInt(0)))
 	memo := "test message"
@@ -9,6 +9,7 @@ require (
 	github.com/cosmos/cosmos-sdk v0.39.3
 	github.com/ethereum/go-ethereum v1.9.11
 	github.com/go-resty/resty/v2 v2.3.0
+	github.com/tendermint/go-amino v0.15.1
 	github.com/tendermint/tendermint v0.33.9
 	github.com/tyler-smith/go-bip39 v1.0.2
 	golang.org/x/crypto v0.0.0-20200728195943-123391ffb6de
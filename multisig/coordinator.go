@@ -0,0 +1,304 @@
+// Package multisig provides coordination of multisig wallets: creation, proposing transfers
+// and collecting signatures of the wallet owners.
+package multisig
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	nodemultisig "bitbucket.org/decimalteam/go-node/x/multisig"
+
+	decapi "bitbucket.org/decimalteam/decimal-go-sdk/api"
+	"bitbucket.org/decimalteam/decimal-go-sdk/wallet"
+)
+
+// Owner contains address and weight of multisig wallet owner.
+type Owner struct {
+	Address string `json:"address"`
+	Weight  uint   `json:"weight"`
+}
+
+// CreatedWallet contains signed transaction creating multisig wallet
+// and address of the wallet which will be created by the transaction.
+type CreatedWallet struct {
+	Address string                    `json:"address"`
+	Tx      auth.StdTx                `json:"tx"`
+	Result  *decapi.BroadcastTxResult `json:"result,omitempty"` // Filled only if transaction is broadcasted
+}
+
+// PendingTransaction contains multisig transaction together with weight of collected signatures.
+type PendingTransaction struct {
+	ID        string          `json:"id"`
+	Wallet    string          `json:"wallet"`
+	Receiver  string          `json:"receiver"`
+	Coins     sdk.Coins       `json:"coins"`
+	Signers   []string        `json:"signers"`   // Addresses of owners already signed the transaction
+	Weight    uint64          `json:"weight"`    // Total weight of collected signatures
+	Threshold uint64          `json:"threshold"` // Weight of signatures needed to execute the transaction
+	Owners    map[string]uint `json:"-"`         // Weights of all wallet owners
+}
+
+// Remaining returns weight of signatures still missing to execute the transaction.
+func (tx *PendingTransaction) Remaining() uint64 {
+	if tx.Weight >= tx.Threshold {
+		return 0
+	}
+	return tx.Threshold - tx.Weight
+}
+
+// SignedBy returns true if owner with specified address has already signed the transaction.
+func (tx *PendingTransaction) SignedBy(owner string) bool {
+	for _, signer := range tx.Signers {
+		if signer == owner {
+			return true
+		}
+	}
+	return false
+}
+
+// SignResult contains result of signing multisig transaction by the owner.
+type SignResult struct {
+	Result           *decapi.BroadcastTxResult `json:"result"`
+	Weight           uint64                    `json:"weight"` // Total weight of signatures including the new one
+	Threshold        uint64                    `json:"threshold"`
+	ThresholdReached bool                      `json:"thresholdReached"` // Transaction is executed by this signature
+}
+
+// Coordinator creates multisig wallets, proposes transfers and signs them as wallet owners.
+// NOTE: Accounts used with coordinator must have chain ID, account number and sequence set up.
+type Coordinator struct {
+	api *decapi.API
+}
+
+// New creates multisig coordinator working through specified API instance.
+func New(api *decapi.API) *Coordinator {
+	return &Coordinator{api: api}
+}
+
+// BuildCreateWallet creates and signs transaction creating multisig wallet with specified owners
+// and threshold. Address of the wallet is derived offline from the signed transaction.
+func (c *Coordinator) BuildCreateWallet(account *wallet.Account, owners []Owner, threshold uint) (*CreatedWallet, error) {
+	sender, err := sdk.AccAddressFromBech32(account.Address())
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]sdk.AccAddress, len(owners))
+	weights := make([]uint, len(owners))
+	for i, owner := range owners {
+		if addresses[i], err = sdk.AccAddressFromBech32(owner.Address); err != nil {
+			return nil, fmt.Errorf("invalid owner address %q: %w", owner.Address, err)
+		}
+		weights[i] = owner.Weight
+	}
+	msg := decapi.NewMsgCreateWallet(sender, addresses, weights, threshold)
+	if err = msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	tx, err := c.signTransaction(account, msg)
+	if err != nil {
+		return nil, err
+	}
+	address, err := c.WalletAddress(tx)
+	if err != nil {
+		return nil, err
+	}
+	return &CreatedWallet{Address: address, Tx: tx}, nil
+}
+
+// CreateWallet creates multisig wallet with specified owners and threshold
+// and returns address of the wallet together with the broadcast result.
+func (c *Coordinator) CreateWallet(account *wallet.Account, owners []Owner, threshold uint) (*CreatedWallet, error) {
+	created, err := c.BuildCreateWallet(account, owners, threshold)
+	if err != nil {
+		return nil, err
+	}
+	created.Result, err = c.api.BroadcastSignedTransactionJSON(created.Tx, account)
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// WalletAddress derives address of multisig wallet created by the signed transaction.
+// The node derives the address from wallet parameters and raw transaction bytes,
+// so the transaction must be broadcasted exactly as passed here.
+func (c *Coordinator) WalletAddress(tx auth.StdTx) (string, error) {
+	var msg *decapi.MsgCreateWallet
+	for _, m := range tx.Msgs {
+		if m, ok := m.(decapi.MsgCreateWallet); ok {
+			msg = &m
+			break
+		}
+	}
+	if msg == nil {
+		return "", errors.New("transaction does not contain multisig/create_wallet message")
+	}
+	txBytes, err := c.api.Codec().MarshalBinaryLengthPrefixed(tx)
+	if err != nil {
+		return "", err
+	}
+	w, err := nodemultisig.NewWallet(msg.Owners, msg.Weights, msg.Threshold, txBytes)
+	if err != nil {
+		return "", err
+	}
+	return w.Address.String(), nil
+}
+
+// ProposeTransfer creates multisig transaction sending coins from the wallet to the receiver.
+// The transaction is signed by the proposing owner automatically.
+func (c *Coordinator) ProposeTransfer(account *wallet.Account, walletAddress string, receiver string, coins sdk.Coins) (*decapi.BroadcastTxResult, error) {
+	sender, err := sdk.AccAddressFromBech32(account.Address())
+	if err != nil {
+		return nil, err
+	}
+	walletAddr, err := sdk.AccAddressFromBech32(walletAddress)
+	if err != nil {
+		return nil, err
+	}
+	receiverAddr, err := sdk.AccAddressFromBech32(receiver)
+	if err != nil {
+		return nil, err
+	}
+	owners, _, err := c.walletOwners(walletAddress)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := owners[account.Address()]; !ok {
+		return nil, fmt.Errorf("account %s is not an owner of multisig wallet %s", account.Address(), walletAddress)
+	}
+	msg := decapi.NewMsgCreateTransaction(sender, walletAddr, receiverAddr, coins)
+	if err = msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	return c.broadcast(account, msg)
+}
+
+// Pending returns transactions of the wallet which have not collected enough signatures yet.
+func (c *Coordinator) Pending(walletAddress string) ([]*PendingTransaction, error) {
+	owners, threshold, err := c.walletOwners(walletAddress)
+	if err != nil {
+		return nil, err
+	}
+	txs, err := c.api.MultisigTransactions(walletAddress)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*PendingTransaction, 0, len(txs))
+	for _, tx := range txs {
+		pending, err := newPendingTransaction(tx, owners, threshold)
+		if err != nil {
+			return nil, err
+		}
+		if pending.Remaining() > 0 {
+			result = append(result, pending)
+		}
+	}
+	return result, nil
+}
+
+// PendingTransaction returns pending transaction of the wallet with specified ID.
+func (c *Coordinator) PendingTransaction(walletAddress string, txID string) (*PendingTransaction, error) {
+	pending, err := c.Pending(walletAddress)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range pending {
+		if tx.ID == txID {
+			return tx, nil
+		}
+	}
+	return nil, fmt.Errorf("pending transaction %s not found in multisig wallet %s", txID, walletAddress)
+}
+
+// Sign signs pending transaction of the wallet as the owner and reports
+// whether collected weight of signatures reaches the threshold.
+func (c *Coordinator) Sign(account *wallet.Account, walletAddress string, txID string) (*SignResult, error) {
+	pending, err := c.PendingTransaction(walletAddress, txID)
+	if err != nil {
+		return nil, err
+	}
+	weight, ok := pending.Owners[account.Address()]
+	if !ok {
+		return nil, fmt.Errorf("account %s is not an owner of multisig wallet %s", account.Address(), walletAddress)
+	}
+	if pending.SignedBy(account.Address()) {
+		return nil, fmt.Errorf("transaction %s is already signed by %s", txID, account.Address())
+	}
+	sender, err := sdk.AccAddressFromBech32(account.Address())
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.broadcast(account, decapi.NewMsgSignTransaction(sender, txID))
+	if err != nil {
+		return nil, err
+	}
+	total := pending.Weight + uint64(weight)
+	return &SignResult{
+		Result:           res,
+		Weight:           total,
+		Threshold:        pending.Threshold,
+		ThresholdReached: total >= pending.Threshold,
+	}, nil
+}
+
+// walletOwners requests weights of the wallet owners and threshold of the wallet.
+func (c *Coordinator) walletOwners(walletAddress string) (map[string]uint, uint64, error) {
+	w, err := c.api.MultisigWallet(walletAddress)
+	if err != nil {
+		return nil, 0, err
+	}
+	owners := make(map[string]uint, len(w.Owners))
+	for _, owner := range w.Owners {
+		owners[owner.Address] = uint(owner.Weight)
+	}
+	return owners, w.Threshold, nil
+}
+
+// newPendingTransaction converts API result using signers data collected by the node.
+func newPendingTransaction(tx *decapi.MultisigTransactionResult, owners map[string]uint, threshold uint64) (*PendingTransaction, error) {
+	coins := sdk.NewCoins()
+	for _, coin := range tx.Coins {
+		amount, err := decapi.ParseAmount(coin.Amount)
+		if err != nil {
+			return nil, err
+		}
+		coins = coins.Add(sdk.NewCoin(coin.Coin, amount.Pip()))
+	}
+	pending := &PendingTransaction{
+		ID:        tx.Transaction,
+		Wallet:    tx.Address,
+		Receiver:  tx.To,
+		Coins:     coins,
+		Threshold: threshold,
+		Owners:    owners,
+	}
+	for signer, data := range tx.Data {
+		pending.Signers = append(pending.Signers, signer)
+		pending.Weight += data.SignerWeight
+	}
+	sort.Strings(pending.Signers)
+	return pending, nil
+}
+
+// signTransaction creates and signs transaction containing the message with fee paid in base coin.
+func (c *Coordinator) signTransaction(account *wallet.Account, msg sdk.Msg) (auth.StdTx, error) {
+	baseCoin, err := c.api.BaseCoin()
+	if err != nil {
+		return auth.StdTx{}, err
+	}
+	feeCoins := sdk.NewCoins(sdk.NewCoin(baseCoin, sdk.ZeroInt()))
+	return c.api.NewSignedTransaction([]sdk.Msg{msg}, feeCoins, "", account)
+}
+
+// broadcast creates, signs and broadcasts transaction containing the message.
+func (c *Coordinator) broadcast(account *wallet.Account, msg sdk.Msg) (*decapi.BroadcastTxResult, error) {
+	tx, err := c.signTransaction(account, msg)
+	if err != nil {
+		return nil, err
+	}
+	return c.api.BroadcastSignedTransactionJSON(tx, account)
+}
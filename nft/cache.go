@@ -0,0 +1,46 @@
+package nft
+
+import (
+	"container/list"
+)
+
+// lruCache is a cache of limited size evicting the least recently used entries. It is not safe for concurrent use.
+type lruCache struct {
+	size    int
+	entries map[string]*list.Element
+	order   *list.List // Front is the most recently used entry
+}
+
+type lruEntry struct {
+	key   string
+	value interface{}
+}
+
+func newLRUCache(size int) *lruCache {
+	return &lruCache{size: size, entries: map[string]*list.Element{}, order: list.New()}
+}
+
+// get returns cached value and marks it as recently used.
+func (c *lruCache) get(key string) (interface{}, bool) {
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*lruEntry).value, true
+}
+
+// put adds or replaces the value and evicts the least recently used entries exceeding the size.
+func (c *lruCache) put(key string, value interface{}) {
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*lruEntry).value = value
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value})
+	for c.order.Len() > c.size {
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.entries, e.Value.(*lruEntry).key)
+	}
+}
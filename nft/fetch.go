@@ -0,0 +1,134 @@
+package nft
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// DefaultMaxFetchSize is a default limit of metadata or cover size loaded by fetchers.
+const DefaultMaxFetchSize = 16 << 20
+
+// Fetcher loads content of NFT metadata or cover by its URI.
+type Fetcher interface {
+	Fetch(ctx context.Context, uri string) ([]byte, error)
+}
+
+// FetcherFunc is an adapter to use ordinary function as Fetcher.
+type FetcherFunc func(ctx context.Context, uri string) ([]byte, error)
+
+// Fetch calls f(ctx, uri).
+func (f FetcherFunc) Fetch(ctx context.Context, uri string) ([]byte, error) {
+	return f(ctx, uri)
+}
+
+// HTTPFetcher loads content by HTTP(S) URI.
+type HTTPFetcher struct {
+	client  *resty.Client
+	maxSize int64
+}
+
+// NewHTTPFetcher creates fetcher using the client (new client with one minute timeout if nil).
+// Content larger than maxSize bytes is rejected, DefaultMaxFetchSize is used if maxSize is not positive.
+func NewHTTPFetcher(client *resty.Client, maxSize int64) *HTTPFetcher {
+	if client == nil {
+		client = resty.New().SetTimeout(time.Minute)
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultMaxFetchSize
+	}
+	return &HTTPFetcher{client: client, maxSize: maxSize}
+}
+
+// Fetch implements Fetcher interface.
+func (f *HTTPFetcher) Fetch(ctx context.Context, uri string) ([]byte, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported scheme of URI %q", uri)
+	}
+	//request
+	res, err := f.client.R().SetContext(ctx).SetDoNotParseResponse(true).Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	body := res.RawBody()
+	defer body.Close()
+	if res.IsError() {
+		return nil, fmt.Errorf("request %s: %s", uri, res.Status())
+	}
+	//process result
+	return readLimited(body, f.maxSize, uri)
+}
+
+// FileFetcher loads content of "file://" URIs and local paths. It is intended for tests and local tools.
+type FileFetcher struct{}
+
+// Fetch implements Fetcher interface.
+func (FileFetcher) Fetch(ctx context.Context, uri string) ([]byte, error) {
+	name := uri
+	if strings.HasPrefix(uri, "file://") {
+		u, err := url.Parse(uri)
+		if err != nil {
+			return nil, err
+		}
+		name = filepath.FromSlash(u.Path)
+	}
+	return readFile(name)
+}
+
+// DirFetcher loads content of any URI from the file with the same path inside the directory,
+// e.g. "https://example.com/nft/meta.json" is loaded from "<Dir>/nft/meta.json".
+// It allows to serve metadata of tests or mirrors without network access.
+type DirFetcher struct {
+	Dir string
+}
+
+// Fetch implements Fetcher interface.
+func (f DirFetcher) Fetch(ctx context.Context, uri string) ([]byte, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	p := u.Path
+	if u.Scheme == "" && u.Host == "" {
+		p = uri
+	}
+	// cleaning of rooted path does not allow to leave the directory
+	p = path.Clean("/" + p)
+	if p == "/" {
+		return nil, fmt.Errorf("URI %q has no path", uri)
+	}
+	return readFile(filepath.Join(f.Dir, filepath.FromSlash(p)))
+}
+
+func readFile(name string) ([]byte, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return readLimited(file, DefaultMaxFetchSize, name)
+}
+
+func readLimited(r io.Reader, maxSize int64, uri string) ([]byte, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("content of %s exceeds %d bytes", uri, maxSize)
+	}
+	return data, nil
+}
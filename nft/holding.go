@@ -0,0 +1,133 @@
+package nft
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	decapi "bitbucket.org/decimalteam/decimal-go-sdk/api"
+)
+
+// SubToken is a sub-token of the NFT.
+type SubToken struct {
+	ID        int64   `json:"id"`
+	Reserve   sdk.Int `json:"reserve"`   // Reserve in pip of base coin
+	Delegated bool    `json:"delegated"` // Delegated sub-token can not be transferred or burned
+}
+
+// Holding contains sub-tokens of the NFT held by the owner.
+type Holding struct {
+	Denom     string     `json:"denom"` // Name of the collection
+	ID        string     `json:"id"`
+	Owner     string     `json:"owner"`
+	SubTokens []SubToken `json:"subTokens"` // Sorted by id
+}
+
+// HoldingFromNFT collects sub-tokens of the NFT (see API.NFTByAddress) held by the owner.
+func HoldingFromNFT(owner string, token *decapi.NFT) (*Holding, error) {
+	h := &Holding{Denom: token.CollectionName, ID: token.Id, Owner: owner}
+	for _, r := range token.NFTReserve {
+		if r.Address != owner {
+			continue
+		}
+		s, err := parseSubToken(r.SubTokenId, r.Reserve, r.Delegated)
+		if err != nil {
+			return nil, fmt.Errorf("NFT %s: %w", token.Id, err)
+		}
+		h.SubTokens = append(h.SubTokens, s)
+	}
+	h.sort()
+	return h, nil
+}
+
+// HoldingFromBalance collects sub-tokens of the NFT from balance of the owner (see API.Address).
+func HoldingFromBalance(owner string, balance *decapi.BalanceNftResult) (*Holding, error) {
+	h := &Holding{Denom: balance.Collection, ID: balance.NftId, Owner: owner}
+	for _, r := range balance.NftReserve {
+		if r.Address != "" && r.Address != owner {
+			continue
+		}
+		s, err := parseSubToken(r.SubTokenId, r.Reserve, r.Delegated)
+		if err != nil {
+			return nil, fmt.Errorf("NFT %s: %w", balance.NftId, err)
+		}
+		h.SubTokens = append(h.SubTokens, s)
+	}
+	h.sort()
+	return h, nil
+}
+
+// Available returns sub-tokens which can be transferred or burned (not delegated).
+func (h *Holding) Available() []SubToken {
+	available := make([]SubToken, 0, len(h.SubTokens))
+	for _, s := range h.SubTokens {
+		if !s.Delegated {
+			available = append(available, s)
+		}
+	}
+	return available
+}
+
+// Pick returns ids of specified amount of available sub-tokens with the smallest ids.
+func (h *Holding) Pick(quantity int) ([]int64, error) {
+	if quantity <= 0 {
+		return nil, fmt.Errorf("quantity must be positive, got %d", quantity)
+	}
+	available := h.Available()
+	if len(available) < quantity {
+		return nil, fmt.Errorf("owner %s holds %d available sub-tokens of NFT %s, requested %d", h.Owner, len(available), h.ID, quantity)
+	}
+	ids := make([]int64, quantity)
+	for i := range ids {
+		ids[i] = available[i].ID
+	}
+	return ids, nil
+}
+
+// Check checks that sub-tokens with specified ids are unique, held by the owner and not delegated.
+func (h *Holding) Check(subTokenIDs []int64) error {
+	if len(subTokenIDs) == 0 {
+		return fmt.Errorf("sub-tokens of NFT %s are not specified", h.ID)
+	}
+	seen := make(map[int64]bool, len(subTokenIDs))
+	for _, id := range subTokenIDs {
+		if seen[id] {
+			return fmt.Errorf("sub-token %d of NFT %s is specified twice", id, h.ID)
+		}
+		seen[id] = true
+		s := h.subToken(id)
+		switch {
+		case s == nil:
+			return fmt.Errorf("owner %s does not hold sub-token %d of NFT %s", h.Owner, id, h.ID)
+		case s.Delegated:
+			return fmt.Errorf("sub-token %d of NFT %s is delegated", id, h.ID)
+		}
+	}
+	return nil
+}
+
+func (h *Holding) subToken(id int64) *SubToken {
+	i := sort.Search(len(h.SubTokens), func(i int) bool { return h.SubTokens[i].ID >= id })
+	if i < len(h.SubTokens) && h.SubTokens[i].ID == id {
+		return &h.SubTokens[i]
+	}
+	return nil
+}
+
+func (h *Holding) sort() {
+	sort.Slice(h.SubTokens, func(i, j int) bool { return h.SubTokens[i].ID < h.SubTokens[j].ID })
+}
+
+func parseSubToken(id, reserve string, delegated bool) (SubToken, error) {
+	subTokenID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return SubToken{}, fmt.Errorf("invalid sub-token id %q", id)
+	}
+	amount, err := decapi.ParseAmount(reserve)
+	if err != nil {
+		return SubToken{}, fmt.Errorf("sub-token %d: %w", subTokenID, err)
+	}
+	return SubToken{ID: subTokenID, Reserve: amount.Pip(), Delegated: delegated}, nil
+}
@@ -0,0 +1,215 @@
+package nft
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"net/url"
+	"strings"
+	"sync"
+
+	decapi "bitbucket.org/decimalteam/decimal-go-sdk/api"
+)
+
+// DefaultResolverCacheSize is a default limit of metadata and cover hashes cached by the resolver.
+const DefaultResolverCacheSize = 1024
+
+// ErrCoverMismatch is returned when hash of the loaded cover differs from the hash of the NFT.
+var ErrCoverMismatch = errors.New("cover hash mismatch")
+
+// Metadata is off-chain description of the NFT referenced by its token URI.
+type Metadata struct {
+	Name        string      `json:"name"`        // Required
+	Description string      `json:"description"` // Optional
+	Image       string      `json:"image"`       // Required, absolute URI of the cover
+	ExternalURL string      `json:"external_url"`
+	Attributes  []Attribute `json:"attributes"`
+}
+
+// Attribute is a trait of the NFT.
+type Attribute struct {
+	TraitType string      `json:"trait_type"`
+	Value     interface{} `json:"value"` // String, number or boolean
+}
+
+// ParseMetadata decodes metadata JSON and validates it.
+func ParseMetadata(data []byte) (*Metadata, error) {
+	m := &Metadata{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("invalid metadata JSON: %w", err)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Validate checks that required fields of metadata are set and URIs are absolute.
+func (m *Metadata) Validate() error {
+	if strings.TrimSpace(m.Name) == "" {
+		return fmt.Errorf("metadata name is not specified")
+	}
+	if m.Image == "" {
+		return fmt.Errorf("metadata image is not specified")
+	}
+	if err := checkURI(m.Image); err != nil {
+		return fmt.Errorf("metadata image: %w", err)
+	}
+	if m.ExternalURL != "" {
+		if err := checkURI(m.ExternalURL); err != nil {
+			return fmt.Errorf("metadata external URL: %w", err)
+		}
+	}
+	for i, a := range m.Attributes {
+		if strings.TrimSpace(a.TraitType) == "" {
+			return fmt.Errorf("metadata attribute %d: trait type is not specified", i)
+		}
+		switch a.Value.(type) {
+		case string, float64, bool:
+		default:
+			return fmt.Errorf("metadata attribute %q: value must be string, number or boolean", a.TraitType)
+		}
+	}
+	return nil
+}
+
+func checkURI(uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return err
+	}
+	if !u.IsAbs() {
+		return fmt.Errorf("URI %q is not absolute", uri)
+	}
+	return nil
+}
+
+// Resolver loads metadata of NFT using the fetcher and verifies covers. Loaded metadata and
+// cover hashes are cached by URI, failed loads are not cached. The least recently used entries
+// are evicted when the cache size is exceeded. Resolver is safe for concurrent use.
+type Resolver struct {
+	fetcher   Fetcher
+	cacheSize int
+
+	mtx      sync.Mutex
+	metadata *lruCache // Metadata by URI
+	covers   *lruCache // Digests of covers by algorithm and URI
+}
+
+// NewResolver creates resolver using the fetcher (HTTP fetcher with default settings if nil)
+// caching up to DefaultResolverCacheSize metadata and cover hashes.
+func NewResolver(fetcher Fetcher) *Resolver {
+	return NewResolverWithCacheSize(fetcher, 0)
+}
+
+// NewResolverWithCacheSize creates resolver using the fetcher (HTTP fetcher with default settings if nil)
+// caching up to cacheSize metadata and cover hashes each, DefaultResolverCacheSize is used if cacheSize is not positive.
+func NewResolverWithCacheSize(fetcher Fetcher, cacheSize int) *Resolver {
+	if fetcher == nil {
+		fetcher = NewHTTPFetcher(nil, 0)
+	}
+	if cacheSize <= 0 {
+		cacheSize = DefaultResolverCacheSize
+	}
+	r := &Resolver{fetcher: fetcher, cacheSize: cacheSize}
+	r.Reset()
+	return r
+}
+
+// Reset clears the cache.
+func (r *Resolver) Reset() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.metadata = newLRUCache(r.cacheSize)
+	r.covers = newLRUCache(r.cacheSize)
+}
+
+// Metadata loads and validates metadata by token URI.
+func (r *Resolver) Metadata(ctx context.Context, tokenURI string) (*Metadata, error) {
+	r.mtx.Lock()
+	cached, ok := r.metadata.get(tokenURI)
+	r.mtx.Unlock()
+	if ok {
+		return cached.(*Metadata), nil
+	}
+	data, err := r.fetcher.Fetch(ctx, tokenURI)
+	if err != nil {
+		return nil, fmt.Errorf("load metadata %s: %w", tokenURI, err)
+	}
+	m, err := ParseMetadata(data)
+	if err != nil {
+		return nil, fmt.Errorf("metadata %s: %w", tokenURI, err)
+	}
+	r.mtx.Lock()
+	r.metadata.put(tokenURI, m)
+	r.mtx.Unlock()
+	return m, nil
+}
+
+// Resolve loads metadata of the NFT and verifies its cover (see VerifyCover).
+func (r *Resolver) Resolve(ctx context.Context, token *decapi.NFT) (*Metadata, error) {
+	if token.TokenURI == "" {
+		return nil, fmt.Errorf("NFT %s has no token URI", token.Id)
+	}
+	m, err := r.Metadata(ctx, token.TokenURI)
+	if err != nil {
+		return nil, fmt.Errorf("NFT %s: %w", token.Id, err)
+	}
+	if err = r.VerifyCover(ctx, token, m); err != nil {
+		return nil, fmt.Errorf("NFT %s: %w", token.Id, err)
+	}
+	return m, nil
+}
+
+// VerifyCover loads the cover referenced by metadata image and checks that its hash matches
+// Misc.CoverHash of the NFT. The hash is hex encoded MD5, SHA-1 or SHA-256 digest distinguished by length.
+// Verification is skipped if the NFT has no cover hash (e.g. it is requested by direct connection).
+func (r *Resolver) VerifyCover(ctx context.Context, token *decapi.NFT, m *Metadata) error {
+	expected := strings.ToLower(strings.TrimSpace(token.Misc.CoverHash))
+	if expected == "" {
+		return nil
+	}
+	var (
+		algorithm string
+		hasher    func() hash.Hash
+	)
+	switch len(expected) {
+	case hex.EncodedLen(md5.Size):
+		algorithm, hasher = "md5", md5.New
+	case hex.EncodedLen(sha1.Size):
+		algorithm, hasher = "sha1", sha1.New
+	case hex.EncodedLen(sha256.Size):
+		algorithm, hasher = "sha256", sha256.New
+	default:
+		return fmt.Errorf("unsupported cover hash %q", token.Misc.CoverHash)
+	}
+	key := algorithm + ":" + m.Image
+	var sum []byte
+	r.mtx.Lock()
+	cached, ok := r.covers.get(key)
+	r.mtx.Unlock()
+	if ok {
+		sum = cached.([]byte)
+	} else {
+		data, err := r.fetcher.Fetch(ctx, m.Image)
+		if err != nil {
+			return fmt.Errorf("load cover %s: %w", m.Image, err)
+		}
+		h := hasher()
+		h.Write(data)
+		sum = h.Sum(nil)
+		r.mtx.Lock()
+		r.covers.put(key, sum)
+		r.mtx.Unlock()
+	}
+	if actual := hex.EncodeToString(sum); actual != expected {
+		return fmt.Errorf("%w: cover %s has hash %s, expected %s", ErrCoverMismatch, m.Image, actual, expected)
+	}
+	return nil
+}
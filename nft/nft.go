@@ -0,0 +1,298 @@
+// Package nft provides helpers for minting and managing NFT: minting tokens of the collection
+// with reserves checked against network minimum and account balance, picking sub-tokens
+// held by the owner for transfer and burn, updating reserves and metadata of sub-tokens,
+// resolving off-chain metadata referenced by token URI and verifying covers of NFT.
+package nft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	decapi "bitbucket.org/decimalteam/decimal-go-sdk/api"
+	"bitbucket.org/decimalteam/decimal-go-sdk/wallet"
+)
+
+// MinReserve is a minimum reserve of sub-token (in pip of base coin) accepted by the network when new NFT is minted.
+var MinReserve = decapi.CoinToPip(sdk.OneDec())
+
+// regName is a pattern of collection name and NFT id checked by the node.
+var regName = regexp.MustCompile("^[a-zA-Z0-9_-]{1,255}$")
+
+// Token describes NFT to mint. Reserve of new NFT is set by Reserve or computed from TotalReserve,
+// additional sub-tokens of existing NFT get reserve of the NFT.
+type Token struct {
+	ID           string
+	TokenURI     string  // Link to metadata of the NFT, must be unique in the network
+	Quantity     int64   // Amount of sub-tokens to mint
+	Reserve      sdk.Int // Reserve of every sub-token in pip of base coin
+	TotalReserve sdk.Int // Reserve of all sub-tokens in pip divided equally (used if Reserve is not set)
+	AllowMint    bool    // Allow the creator to mint additional sub-tokens later
+}
+
+// ReservePerSubToken divides total reserve equally among sub-tokens. Remainder of division is not reserved.
+func ReservePerSubToken(totalReserve sdk.Int, quantity int64) (sdk.Int, error) {
+	if quantity <= 0 {
+		return sdk.Int{}, fmt.Errorf("quantity must be positive, got %d", quantity)
+	}
+	if totalReserve.BigInt() == nil || !totalReserve.IsPositive() {
+		return sdk.Int{}, fmt.Errorf("total reserve must be positive")
+	}
+	return totalReserve.QuoRaw(quantity), nil
+}
+
+// Manager mints and manages NFT of the account.
+type Manager struct {
+	api     *decapi.API
+	account *wallet.Account
+	address sdk.AccAddress
+}
+
+// New creates manager of NFT owned by the account.
+func New(api *decapi.API, account *wallet.Account) (*Manager, error) {
+	address, err := sdk.AccAddressFromBech32(account.Address())
+	if err != nil {
+		return nil, err
+	}
+	return &Manager{api: api, account: account, address: address}, nil
+}
+
+// Address returns address of the account.
+func (m *Manager) Address() string {
+	return m.address.String()
+}
+
+// Holding requests sub-tokens of the NFT held by the account.
+func (m *Manager) Holding(denom, id string) (*Holding, error) {
+	tokens, err := m.api.NFTByAddress(m.Address())
+	if err != nil {
+		return nil, err
+	}
+	for _, token := range tokens {
+		if token.CollectionName == denom && token.Id == id {
+			return HoldingFromNFT(m.Address(), token)
+		}
+	}
+	return nil, fmt.Errorf("account %s does not hold NFT %s of collection %s", m.Address(), id, denom)
+}
+
+// Mint broadcasts transaction minting the NFT of the collection to the recipient (the account if empty).
+func (m *Manager) Mint(ctx context.Context, denom string, recipient string, token Token) (*decapi.BroadcastTxResult, error) {
+	return m.MintCollection(ctx, denom, recipient, token)
+}
+
+// MintCollection broadcasts single transaction minting several NFT of the collection to the recipient
+// (the account if empty). Before broadcasting it checks names, reserves of new NFT against MinReserve,
+// that additional sub-tokens of existing NFT are allowed to be minted and the account is its creator,
+// and that the account balance covers reserves of all minted sub-tokens.
+func (m *Manager) MintCollection(ctx context.Context, denom string, recipient string, tokens ...Token) (*decapi.BroadcastTxResult, error) {
+	if !regName.MatchString(denom) {
+		return nil, fmt.Errorf("invalid collection name %q", denom)
+	}
+	if len(tokens) == 0 {
+		return nil, errors.New("no tokens to mint")
+	}
+	recipientAddress := m.address
+	if recipient != "" {
+		var err error
+		if recipientAddress, err = sdk.AccAddressFromBech32(recipient); err != nil {
+			return nil, fmt.Errorf("invalid recipient address %q: %w", recipient, err)
+		}
+	}
+
+	builder := m.api.NewTxBuilder().Signer(m.account)
+	cost := sdk.ZeroInt()
+	for _, token := range tokens {
+		reserve, err := m.mintReserve(denom, token)
+		if err != nil {
+			return nil, err
+		}
+		cost = cost.Add(reserve.MulRaw(token.Quantity))
+		builder.Msg(decapi.NewMsgMintNFT(m.address, recipientAddress, token.ID, denom, token.TokenURI,
+			sdk.NewInt(token.Quantity), reserve, token.AllowMint))
+	}
+	if err := m.checkBalance(cost); err != nil {
+		return nil, err
+	}
+	return builder.Broadcast(ctx)
+}
+
+// mintReserve checks the token and returns reserve of its sub-tokens.
+func (m *Manager) mintReserve(denom string, token Token) (sdk.Int, error) {
+	if !regName.MatchString(token.ID) {
+		return sdk.Int{}, fmt.Errorf("invalid NFT id %q", token.ID)
+	}
+	if token.Quantity <= 0 {
+		return sdk.Int{}, fmt.Errorf("NFT %s: quantity must be positive, got %d", token.ID, token.Quantity)
+	}
+	existing, err := m.existingNFT(token.ID)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	if existing != nil {
+		if existing.CollectionName != denom {
+			return sdk.Int{}, fmt.Errorf("NFT %s already exists in collection %s", token.ID, existing.CollectionName)
+		}
+		if existing.Creator != "" && existing.Creator != m.Address() {
+			return sdk.Int{}, fmt.Errorf("NFT %s is created by %s, only its creator can mint additional sub-tokens", token.ID, existing.Creator)
+		}
+		if !existing.AllowMint {
+			return sdk.Int{}, fmt.Errorf("minting of additional sub-tokens of NFT %s is not allowed", token.ID)
+		}
+		reserve, err := existing.StartReserveAmount()
+		if err != nil {
+			return sdk.Int{}, fmt.Errorf("NFT %s: %w", token.ID, err)
+		}
+		return reserve.Pip(), nil
+	}
+
+	reserve := token.Reserve
+	if reserve.BigInt() == nil {
+		if reserve, err = ReservePerSubToken(token.TotalReserve, token.Quantity); err != nil {
+			return sdk.Int{}, fmt.Errorf("NFT %s: %w", token.ID, err)
+		}
+	}
+	if reserve.LT(MinReserve) {
+		return sdk.Int{}, fmt.Errorf("NFT %s: reserve of sub-token %s pip is less than minimum reserve %s pip", token.ID, reserve, MinReserve)
+	}
+	return reserve, nil
+}
+
+// existingNFT requests the NFT with specified id and returns nil if it does not exist.
+func (m *Manager) existingNFT(id string) (*decapi.NFT, error) {
+	token, err := m.api.NFT(id)
+	if err != nil {
+		switch {
+		case decapi.IsNotFound(err):
+			return nil, nil
+		case errors.Is(err, decapi.ErrNotImplemented):
+			// NFT can not be requested, the node rejects minting of existing NFT without permission anyway
+			return nil, nil
+		}
+		return nil, err
+	}
+	return token, nil
+}
+
+// Transfer broadcasts transaction transferring specified amount of sub-tokens of the NFT to the recipient.
+// Available sub-tokens with the smallest ids are transferred.
+func (m *Manager) Transfer(ctx context.Context, recipient string, denom, id string, quantity int) (*decapi.BroadcastTxResult, error) {
+	holding, err := m.Holding(denom, id)
+	if err != nil {
+		return nil, err
+	}
+	subTokenIDs, err := holding.Pick(quantity)
+	if err != nil {
+		return nil, err
+	}
+	return m.transfer(ctx, recipient, denom, id, subTokenIDs)
+}
+
+// TransferSubTokens broadcasts transaction transferring sub-tokens of the NFT with specified ids to the recipient.
+func (m *Manager) TransferSubTokens(ctx context.Context, recipient string, denom, id string, subTokenIDs []int64) (*decapi.BroadcastTxResult, error) {
+	holding, err := m.Holding(denom, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = holding.Check(subTokenIDs); err != nil {
+		return nil, err
+	}
+	return m.transfer(ctx, recipient, denom, id, subTokenIDs)
+}
+
+func (m *Manager) transfer(ctx context.Context, recipient string, denom, id string, subTokenIDs []int64) (*decapi.BroadcastTxResult, error) {
+	recipientAddress, err := sdk.AccAddressFromBech32(recipient)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient address %q: %w", recipient, err)
+	}
+	if recipientAddress.Equals(m.address) {
+		return nil, errors.New("NFT can not be transferred to the sender")
+	}
+	msg := decapi.NewMsgTransferNFT(m.address, recipientAddress, denom, id, subTokenIDs)
+	return m.api.NewTxBuilder().Signer(m.account).Msg(msg).Broadcast(ctx)
+}
+
+// Burn broadcasts transaction burning specified amount of sub-tokens of the NFT with the smallest ids.
+// Only the creator of the NFT can burn its sub-tokens, reserves of burned sub-tokens are returned to the creator.
+func (m *Manager) Burn(ctx context.Context, denom, id string, quantity int) (*decapi.BroadcastTxResult, error) {
+	holding, err := m.Holding(denom, id)
+	if err != nil {
+		return nil, err
+	}
+	subTokenIDs, err := holding.Pick(quantity)
+	if err != nil {
+		return nil, err
+	}
+	return m.burn(ctx, denom, id, subTokenIDs)
+}
+
+// BurnSubTokens broadcasts transaction burning sub-tokens of the NFT with specified ids.
+func (m *Manager) BurnSubTokens(ctx context.Context, denom, id string, subTokenIDs []int64) (*decapi.BroadcastTxResult, error) {
+	holding, err := m.Holding(denom, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = holding.Check(subTokenIDs); err != nil {
+		return nil, err
+	}
+	return m.burn(ctx, denom, id, subTokenIDs)
+}
+
+func (m *Manager) burn(ctx context.Context, denom, id string, subTokenIDs []int64) (*decapi.BroadcastTxResult, error) {
+	msg := decapi.NewMsgBurnNFT(m.address, id, denom, subTokenIDs)
+	return m.api.NewTxBuilder().Signer(m.account).Msg(msg).Broadcast(ctx)
+}
+
+// UpdateReserve broadcasts transaction increasing reserve of sub-tokens of the NFT with specified ids.
+// New reserve must be greater than current reserve of every sub-token, the difference is paid by the creator.
+func (m *Manager) UpdateReserve(ctx context.Context, denom, id string, subTokenIDs []int64, reserve sdk.Int) (*decapi.BroadcastTxResult, error) {
+	holding, err := m.Holding(denom, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = holding.Check(subTokenIDs); err != nil {
+		return nil, err
+	}
+	cost := sdk.ZeroInt()
+	for _, subTokenID := range subTokenIDs {
+		current := holding.subToken(subTokenID).Reserve
+		if reserve.LTE(current) {
+			return nil, fmt.Errorf("new reserve %s pip must be greater than reserve %s pip of sub-token %d", reserve, current, subTokenID)
+		}
+		cost = cost.Add(reserve.Sub(current))
+	}
+	if err = m.checkBalance(cost); err != nil {
+		return nil, err
+	}
+	msg := decapi.NewMsgUpdateReserveNFT(m.address, id, denom, subTokenIDs, reserve)
+	return m.api.NewTxBuilder().Signer(m.account).Msg(msg).Broadcast(ctx)
+}
+
+// EditMetadata broadcasts transaction replacing link to metadata of the NFT. Only the creator can edit it.
+func (m *Manager) EditMetadata(ctx context.Context, denom, id string, tokenURI string) (*decapi.BroadcastTxResult, error) {
+	msg := decapi.NewMsgEditNFTMetadata(m.address, id, denom, tokenURI)
+	return m.api.NewTxBuilder().Signer(m.account).Msg(msg).Broadcast(ctx)
+}
+
+// checkBalance checks that the account has specified amount of base coin (in pip) for reserves.
+func (m *Manager) checkBalance(amount sdk.Int) error {
+	baseCoin, err := m.api.BaseCoin()
+	if err != nil {
+		return err
+	}
+	address, err := m.api.Address(m.Address())
+	if err != nil {
+		return err
+	}
+	balance, err := address.BalanceOf(baseCoin)
+	if err != nil {
+		return err
+	}
+	if balance.Pip().LT(amount) {
+		return fmt.Errorf("balance %s pip of %s is not enough to reserve %s pip", balance.Pip(), baseCoin, amount)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	decapi "bitbucket.org/decimalteam/decimal-go-sdk/api"
+)
+
+// Default monitoring configuration values.
+const (
+	DefaultMonitorInterval        = time.Minute
+	DefaultSkippedBlocksThreshold = 1
+)
+
+// ErrSkippedBlocksUnsupported is returned by Monitor on direct connection to the node unless
+// MonitorConfig.StatusOnly is set: the node does not report skipped blocks of the validator.
+var ErrSkippedBlocksUnsupported = errors.New("skipped blocks are not reported by direct connection, set StatusOnly to monitor status only")
+
+// AlertKind is a kind of the monitoring alert.
+type AlertKind string
+
+// Kinds of monitoring alerts.
+const (
+	AlertSkippedBlocks AlertKind = "skipped_blocks" // Validator skipped blocks since previous check
+	AlertStatus        AlertKind = "status"         // Validator status (online, offline) or kind (Validator, Candidate) changed, or it is offline on the first check
+	AlertUnavailable   AlertKind = "unavailable"    // Unable to request information about the validator
+)
+
+// Alert contains information about event noticed by the monitor.
+type Alert struct {
+	Kind      AlertKind
+	Time      time.Time
+	Message   string
+	Validator *decapi.ValidatorResult // Current state of the validator (nil for AlertUnavailable)
+	Previous  *decapi.ValidatorResult // State of the validator on previous successful check (nil on the first check)
+	Err       error                   // Request error (only for AlertUnavailable)
+}
+
+// MonitorConfig contains monitoring configuration, zero values are replaced by defaults.
+type MonitorConfig struct {
+	Interval               time.Duration // Interval between checks of the validator
+	SkippedBlocksThreshold uint64        // Minimum growth of skipped blocks between checks to raise the alert
+	StatusOnly             bool          // Do not monitor skipped blocks (required on direct connection)
+}
+
+// Monitor checks the validator periodically and calls alert function on skipped blocks growth,
+// status changes and request errors. The validator which is offline on the first check is reported too.
+// It blocks until the context is done and returns its error.
+// Skipped blocks are reported only by gateway, so on direct connection ErrSkippedBlocksUnsupported
+// is returned unless cfg.StatusOnly is set.
+func (op *Operator) Monitor(ctx context.Context, cfg MonitorConfig, alert func(Alert)) error {
+	if op.api.IsDirectConn() && !cfg.StatusOnly {
+		return ErrSkippedBlocksUnsupported
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultMonitorInterval
+	}
+	if cfg.SkippedBlocksThreshold == 0 {
+		cfg.SkippedBlocksThreshold = DefaultSkippedBlocksThreshold
+	}
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+
+	var previous *decapi.ValidatorResult
+	for {
+		current, err := op.Validator()
+		now := time.Now()
+		if err != nil {
+			alert(Alert{
+				Kind:     AlertUnavailable,
+				Time:     now,
+				Message:  fmt.Sprintf("unable to request validator %s: %s", op.Address(), err.Error()),
+				Previous: previous,
+				Err:      err,
+			})
+		} else {
+			for _, a := range checkValidator(previous, current, cfg) {
+				a.Time = now
+				alert(a)
+			}
+			previous = current
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkValidator compares current state of the validator with the previous one and returns alerts.
+func checkValidator(previous, current *decapi.ValidatorResult, cfg MonitorConfig) []Alert {
+	if previous == nil {
+		if current.Status == "online" {
+			return nil
+		}
+		return []Alert{{
+			Kind:      AlertStatus,
+			Message:   fmt.Sprintf("validator %s is %s (%s)", current.Address, current.Status, current.Kind),
+			Validator: current,
+		}}
+	}
+	var alerts []Alert
+	if current.Status != previous.Status || current.Kind != previous.Kind {
+		alerts = append(alerts, Alert{
+			Kind: AlertStatus,
+			Message: fmt.Sprintf("validator %s changed status from %s (%s) to %s (%s)",
+				current.Address, previous.Status, previous.Kind, current.Status, current.Kind),
+			Validator: current,
+			Previous:  previous,
+		})
+	}
+	if !cfg.StatusOnly && current.SkippedBlocks >= previous.SkippedBlocks+cfg.SkippedBlocksThreshold {
+		alerts = append(alerts, Alert{
+			Kind: AlertSkippedBlocks,
+			Message: fmt.Sprintf("validator %s skipped %d blocks since previous check (%d in total)",
+				current.Address, current.SkippedBlocks-previous.SkippedBlocks, current.SkippedBlocks),
+			Validator: current,
+			Previous:  previous,
+		})
+	}
+	return alerts
+}
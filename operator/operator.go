@@ -0,0 +1,159 @@
+// Package operator provides tools for masternode operators: declaring the candidate
+// with consensus key of the node, editing its description, switching it online/offline
+// and monitoring the validator for missed blocks and status changes.
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	amino "github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/crypto"
+	cryptoamino "github.com/tendermint/tendermint/crypto/encoding/amino"
+
+	decapi "bitbucket.org/decimalteam/decimal-go-sdk/api"
+	"bitbucket.org/decimalteam/decimal-go-sdk/wallet"
+)
+
+// KeepField is a value of description field which keeps current value of the field when editing the candidate.
+const KeepField = "[do-not-modify]"
+
+// keyCodec decodes keys stored by Tendermint.
+var keyCodec = func() *amino.Codec {
+	cdc := amino.NewCodec()
+	cryptoamino.RegisterAmino(cdc)
+	return cdc
+}()
+
+// LoadConsensusPubKey reads consensus public key of the node from Tendermint
+// validator key file (usually "config/priv_validator_key.json" in the node home directory).
+// Private key stored in the file is not decoded.
+func LoadConsensusPubKey(path string) (crypto.PubKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConsensusPubKey(data)
+}
+
+// ParseConsensusPubKey parses consensus public key of the node from contents of Tendermint validator key file.
+func ParseConsensusPubKey(data []byte) (crypto.PubKey, error) {
+	file := struct {
+		PubKey json.RawMessage `json:"pub_key"`
+	}{}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("unable to parse validator key file: %w", err)
+	}
+	if len(file.PubKey) == 0 {
+		return nil, errors.New("validator key file does not contain public key")
+	}
+	var pubKey crypto.PubKey
+	if err := keyCodec.UnmarshalJSON(file.PubKey, &pubKey); err != nil {
+		return nil, fmt.Errorf("unable to parse consensus public key: %w", err)
+	}
+	return pubKey, nil
+}
+
+// Operator manages the validator controlled by the account.
+// Address of the validator ("dxvaloper...") is derived from the account address.
+type Operator struct {
+	api     *decapi.API
+	account *wallet.Account
+	address sdk.ValAddress
+}
+
+// New creates operator of the validator controlled by the account.
+func New(api *decapi.API, account *wallet.Account) (*Operator, error) {
+	accAddress, err := sdk.AccAddressFromBech32(account.Address())
+	if err != nil {
+		return nil, err
+	}
+	return &Operator{api: api, account: account, address: sdk.ValAddress(accAddress)}, nil
+}
+
+// Address returns address of the validator ("dxvaloper...").
+func (op *Operator) Address() string {
+	return op.address.String()
+}
+
+// Validator requests current information about the validator.
+func (op *Operator) Validator() (*decapi.ValidatorResult, error) {
+	return op.api.Validator(op.Address())
+}
+
+// Declare broadcasts transaction declaring the candidate with consensus public key of the node
+// (see LoadConsensusPubKey), commission (0.1 means 10%) and initial stake of the operator.
+// Rewards are sent to the operator account if reward address is empty.
+func (op *Operator) Declare(ctx context.Context, pubKey crypto.PubKey, commission sdk.Dec, stake sdk.Coin, description decapi.Description, rewardAddress string) (*decapi.BroadcastTxResult, error) {
+	if pubKey == nil {
+		return nil, errors.New("consensus public key is not specified")
+	}
+	description, err := description.EnsureLength()
+	if err != nil {
+		return nil, err
+	}
+	reward, err := op.rewardAddress(rewardAddress)
+	if err != nil {
+		return nil, err
+	}
+	msg := decapi.NewMsgDeclareCandidate(op.address, pubKey, commission, stake, description, reward)
+	return op.broadcast(ctx, msg)
+}
+
+// Edit broadcasts transaction replacing description and reward address of the validator.
+// Description fields equal to KeepField and empty reward address keep current values.
+func (op *Operator) Edit(ctx context.Context, description decapi.Description, rewardAddress string) (*decapi.BroadcastTxResult, error) {
+	current, err := op.Validator()
+	if err != nil {
+		return nil, err
+	}
+	description, err = decapi.Description{
+		Moniker:         current.Moniker,
+		Identity:        current.Identity,
+		Website:         current.Website,
+		SecurityContact: current.SecurityContact,
+		Details:         current.Details,
+	}.UpdateDescription(description)
+	if err != nil {
+		return nil, err
+	}
+	if rewardAddress == "" {
+		rewardAddress = current.RewardAddress
+	}
+	reward, err := op.rewardAddress(rewardAddress)
+	if err != nil {
+		return nil, err
+	}
+	msg := decapi.NewMsgEditCandidate(op.address, reward, description)
+	return op.broadcast(ctx, msg)
+}
+
+// SetOnline broadcasts transaction turning the validator on (it starts to participate in block production).
+func (op *Operator) SetOnline(ctx context.Context) (*decapi.BroadcastTxResult, error) {
+	return op.broadcast(ctx, decapi.NewMsgSetOnline(op.address))
+}
+
+// SetOffline broadcasts transaction turning the validator off (e.g. before maintenance of the node).
+func (op *Operator) SetOffline(ctx context.Context) (*decapi.BroadcastTxResult, error) {
+	return op.broadcast(ctx, decapi.NewMsgSetOffline(op.address))
+}
+
+// rewardAddress parses reward address or returns address of the operator account if it is empty.
+func (op *Operator) rewardAddress(address string) (sdk.AccAddress, error) {
+	if address == "" {
+		address = op.account.Address()
+	}
+	reward, err := sdk.AccAddressFromBech32(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reward address %q: %w", address, err)
+	}
+	return reward, nil
+}
+
+func (op *Operator) broadcast(ctx context.Context, msg sdk.Msg) (*decapi.BroadcastTxResult, error) {
+	return op.api.NewTxBuilder().Signer(op.account).Msg(msg).Broadcast(ctx)
+}
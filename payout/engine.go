@@ -0,0 +1,544 @@
+// Package payout provides bulk payouts: payments to many recipients are grouped into
+// multi-send transactions, broadcasted with sequential nonces and tracked in a journal file,
+// so interrupted payout can be resumed without paying any entry twice.
+package payout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	decapi "bitbucket.org/decimalteam/decimal-go-sdk/api"
+	"bitbucket.org/decimalteam/decimal-go-sdk/wallet"
+)
+
+// Default configuration values.
+const (
+	DefaultMaxBytes       = 32 << 10
+	DefaultConcurrency    = 4
+	DefaultPollInterval   = 2 * time.Second
+	DefaultConfirmTimeout = time.Minute
+)
+
+// Entry is a single payment of the payout.
+type Entry struct {
+	ID      string  `json:"id"`      // Optional unique ID of the payment used to track it in the journal
+	Address string  `json:"address"` // Address of the recipient
+	Coin    string  `json:"coin"`    // Symbol of the coin
+	Amount  sdk.Int `json:"amount"`  // Amount of coins in pip
+}
+
+// Status is a status of the payout entry.
+type Status string
+
+// Statuses of payout entries.
+const (
+	StatusPending   Status = "pending"   // Entry is not paid yet
+	StatusInvalid   Status = "invalid"   // Entry contains invalid address, coin or amount
+	StatusConfirmed Status = "confirmed" // Entry is paid
+	StatusFailed    Status = "failed"    // Transaction paying the entry failed, entry will be paid on the next run
+	StatusUnknown   Status = "unknown"   // Transaction paying the entry is not confirmed yet, it is checked on the next run
+)
+
+// EntryResult contains status of the payout entry.
+type EntryResult struct {
+	Entry  Entry  `json:"entry"`
+	Key    string `json:"key"` // Key of the entry in the journal
+	Status Status `json:"status"`
+	TxHash string `json:"txHash,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report contains results of all payout entries in the order they were passed to Run.
+type Report struct {
+	Entries []*EntryResult `json:"entries"`
+}
+
+// Counts returns amount of entries with each status.
+func (r *Report) Counts() map[Status]int {
+	counts := map[Status]int{}
+	for _, e := range r.Entries {
+		counts[e.Status]++
+	}
+	return counts
+}
+
+// Config contains payout engine parameters.
+type Config struct {
+	JournalPath    string        // Path to the journal file, required
+	MaxBytes       int           // Maximum size of the transaction in bytes (DefaultMaxBytes if zero)
+	MaxFeeUnits    decapi.Fee    // Maximum fee of the transaction in units (no limit if zero)
+	FeeCoin        string        // Coin to pay fees (base coin if empty)
+	Memo           string        // Memo of transactions
+	Concurrency    int           // Maximum amount of broadcasted but not confirmed transactions (DefaultConcurrency if zero)
+	PollInterval   time.Duration // Interval of checking transaction confirmation (DefaultPollInterval if zero)
+	ConfirmTimeout time.Duration // Time to wait for transaction confirmation (DefaultConfirmTimeout if zero)
+}
+
+// Engine pays entries from the account.
+// NOTE: Account must not send other transactions while payout is running.
+type Engine struct {
+	api     *decapi.API
+	account *wallet.Account
+	cfg     Config
+}
+
+// New creates payout engine paying from the account through specified API instance.
+func New(api *decapi.API, account *wallet.Account, cfg Config) *Engine {
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = DefaultMaxBytes
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = DefaultConcurrency
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	if cfg.ConfirmTimeout <= 0 {
+		cfg.ConfirmTimeout = DefaultConfirmTimeout
+	}
+	cfg.FeeCoin = strings.ToLower(cfg.FeeCoin)
+	return &Engine{api: api, account: account, cfg: cfg}
+}
+
+// job is a batch transaction to broadcast.
+type job struct {
+	sequence uint64
+	tx       auth.StdTx
+	hash     string
+	entries  []*EntryResult
+	resumed  bool // Transaction was signed by previous run
+}
+
+// run contains state of single payout run.
+type run struct {
+	*Engine
+	journal *journal
+	results map[string]*EntryResult
+	mtx     sync.Mutex
+
+	sender    sdk.AccAddress
+	feeCoin   string    // Custom coin to pay fees, empty for base coin
+	sizingFee sdk.Coins // Fee used to size batches, not smaller than actual fee of any batch
+}
+
+// Run pays all entries which are not paid yet according to the journal.
+// Transactions left unconfirmed by previous run are broadcasted again and confirmed first:
+// the same signed transaction can not be executed twice, so entries are never paid twice.
+// Entries of transactions failed during this run are paid again only on the next run.
+// Transaction left by previous run which is rejected by the node while its sequence is not used yet
+// is dropped, its entries are paid again by new transactions using its sequence.
+// If broadcasting fails without a response of the node (e.g. timeout), the transaction may be
+// accepted anyway, so the run stops and the transaction is checked or broadcasted again on the next run.
+func (e *Engine) Run(ctx context.Context, entries []Entry) (*Report, error) {
+	if e.cfg.JournalPath == "" {
+		return nil, errors.New("journal path is required")
+	}
+	j, err := openJournal(e.cfg.JournalPath)
+	if err != nil {
+		return nil, err
+	}
+	defer j.close()
+
+	r := &run{Engine: e, journal: j, results: map[string]*EntryResult{}}
+	report := r.restore(entries)
+	err = r.pay(ctx)
+	return report, err
+}
+
+// restore creates results of the entries and restores their statuses from the journal.
+func (r *run) restore(entries []Entry) *Report {
+	report := &Report{Entries: make([]*EntryResult, len(entries))}
+	occurrences := map[string]int{}
+	for i, entry := range entries {
+		entry.Coin = strings.ToLower(entry.Coin)
+		key := entry.ID
+		if key == "" {
+			// Identical entries are distinguished by the order of occurrence
+			key = fmt.Sprintf("%s/%s%s", entry.Address, entry.Amount, entry.Coin)
+			occurrences[key]++
+			key = fmt.Sprintf("%s#%d", key, occurrences[key])
+		}
+		result := &EntryResult{Entry: entry, Key: key, Status: StatusPending}
+		if err := validateEntry(entry); err != nil {
+			result.Status, result.Error = StatusInvalid, err.Error()
+		} else if _, ok := r.results[key]; ok {
+			result.Status, result.Error = StatusInvalid, fmt.Sprintf("duplicate entry key %q", key)
+		} else {
+			r.results[key] = result
+		}
+		report.Entries[i] = result
+	}
+	// Later batches override statuses of entries paid by earlier failed ones
+	for _, b := range r.journal.batches {
+		for _, key := range b.entries {
+			if result, ok := r.results[key]; ok {
+				r.setBatchStatus(result, b)
+			}
+		}
+	}
+	return report
+}
+
+func validateEntry(entry Entry) error {
+	if _, err := sdk.AccAddressFromBech32(entry.Address); err != nil {
+		return fmt.Errorf("invalid address %q: %w", entry.Address, err)
+	}
+	if err := sdk.ValidateDenom(entry.Coin); err != nil {
+		return err
+	}
+	if entry.Amount.BigInt() == nil || !entry.Amount.IsPositive() {
+		return errors.New("amount must be positive")
+	}
+	return nil
+}
+
+// setBatchStatus sets status of the entry according to the status of the batch paying it.
+func (r *run) setBatchStatus(result *EntryResult, b *batch) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	result.TxHash, result.Error = b.hash, b.err
+	switch b.status {
+	case BatchConfirmed:
+		result.Status = StatusConfirmed
+	case BatchSigned:
+		result.Status = StatusUnknown
+	default:
+		result.Status = StatusFailed
+	}
+}
+
+// pay broadcasts transactions left by previous run, then signs and broadcasts new batches.
+func (r *run) pay(ctx context.Context) error {
+	chainID, err := r.api.ChainID()
+	if err != nil {
+		return err
+	}
+	accountNumber, sequence, err := r.api.AccountNumberAndSequence(r.account.Address())
+	if err != nil {
+		return err
+	}
+	r.account.WithChainID(chainID).WithAccountNumber(accountNumber)
+	chainSequence := sequence
+	if r.sender, err = sdk.AccAddressFromBech32(r.account.Address()); err != nil {
+		return err
+	}
+	if err = r.prepareFee(); err != nil {
+		return err
+	}
+
+	var resumed []*job
+	for _, b := range r.journal.batches {
+		if b.status != BatchSigned {
+			continue
+		}
+		tx := auth.StdTx{}
+		if err = r.api.Codec().UnmarshalBinaryLengthPrefixed(b.tx, &tx); err != nil {
+			return fmt.Errorf("unable to decode transaction %s from the journal: %w", b.hash, err)
+		}
+		jb := &job{sequence: b.sequence, tx: tx, hash: b.hash, resumed: true}
+		for _, key := range b.entries {
+			if result, ok := r.results[key]; ok && result.TxHash == b.hash {
+				jb.entries = append(jb.entries, result)
+			}
+		}
+		resumed = append(resumed, jb)
+		if b.sequence >= sequence {
+			sequence = b.sequence + 1
+		}
+	}
+	sort.Slice(resumed, func(i, k int) bool { return resumed[i].sequence < resumed[k].sequence })
+
+	// Entries of transactions failed during previous runs are paid again
+	var pending []*EntryResult
+	for _, result := range r.results {
+		if result.Status == StatusPending || result.Status == StatusFailed {
+			pending = append(pending, result)
+		}
+	}
+	sort.Slice(pending, func(i, k int) bool { return pending[i].Key < pending[k].Key })
+
+	var (
+		wg           sync.WaitGroup
+		slots        = make(chan struct{}, r.cfg.Concurrency)
+		firstErr     error
+		broadcastErr error
+	)
+	defer wg.Wait()
+	confirm := func(jb *job) {
+		defer wg.Done()
+		defer func() { <-slots }()
+		if err := r.confirm(ctx, jb); err != nil {
+			r.mtx.Lock()
+			if firstErr == nil {
+				firstErr = err
+			}
+			r.mtx.Unlock()
+		}
+	}
+	// Transactions are broadcasted in order of sequences, only confirmation is concurrent
+	for next := 0; broadcastErr == nil && (len(resumed) > 0 || next < len(pending)); {
+		var jb *job
+		if len(resumed) > 0 {
+			jb, resumed = resumed[0], resumed[1:]
+		} else {
+			var entries []*EntryResult
+			if entries, err = r.nextBatch(pending[next:]); err != nil {
+				return err
+			}
+			next += len(entries)
+			if jb, err = r.sign(entries, sequence); err != nil {
+				return err
+			}
+			sequence++
+		}
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		_, err = r.api.BroadcastTransaction(ctx, jb.tx)
+		var txErr decapi.TxError
+		rejected := err != nil && errors.As(err, &txErr) && txErr.Code != 0
+		if rejected && jb.resumed && jb.sequence >= chainSequence && !pendingInMempool(txErr) {
+			// Resumed transaction is not executed (its sequence is not used yet) and the node rejects it,
+			// so it is dropped: its entries are paid again and its sequence is used by new transactions
+			<-slots
+			if err = r.record(jb, BatchRejected, err.Error()); err != nil {
+				return err
+			}
+			pending = append(pending, jb.entries...)
+			if jb.sequence < sequence {
+				sequence = jb.sequence
+			}
+			continue
+		}
+		if err != nil && !jb.resumed {
+			// Later transactions can not be executed without this one
+			if rejected {
+				<-slots
+				return r.finish(jb, BatchRejected, err.Error())
+			}
+			// The node may have accepted the transaction before the error (e.g. timeout), so it is left
+			// signed: it is confirmed below if executed, otherwise broadcasted again on the next run
+			broadcastErr = fmt.Errorf("unable to broadcast transaction %s, it is checked on the next run: %w", jb.hash, err)
+		}
+		// Resumed transaction may be already executed, so broadcast error is expected
+		wg.Add(1)
+		go confirm(jb)
+	}
+	wg.Wait()
+	if broadcastErr != nil {
+		return broadcastErr
+	}
+	return firstErr
+}
+
+// prepareFee resolves the fee coin and the fee used to size batches. Fee in base coin is left empty
+// (the node charges required amount). Fee in custom coin is sized with the whole volume of the coin,
+// so the actual fee is never longer and the signed transaction never exceeds the sized one.
+func (r *run) prepareFee() error {
+	baseCoin, err := r.api.BaseCoin()
+	if err != nil {
+		return err
+	}
+	r.feeCoin, r.sizingFee = "", nil
+	if r.cfg.FeeCoin == "" || r.cfg.FeeCoin == baseCoin {
+		return nil
+	}
+	coin, err := r.api.Coin(r.cfg.FeeCoin)
+	if err != nil {
+		return err
+	}
+	if coin == nil || coin.Symbol == "" {
+		return fmt.Errorf("fee coin %q does not exist", r.cfg.FeeCoin)
+	}
+	volume, err := coin.VolumeAmount()
+	if err != nil {
+		return err
+	}
+	r.feeCoin = r.cfg.FeeCoin
+	r.sizingFee = sdk.NewCoins(sdk.NewCoin(r.feeCoin, volume.Pip()))
+	return nil
+}
+
+// fee returns fee coins of the transaction with the message. Fee in custom coin depends on the size
+// of the transaction containing it, so it is increased until it covers the transaction.
+func (r *run) fee(msg sdk.Msg) (sdk.Coins, error) {
+	const maxFeeIterations = 8
+	if r.feeCoin == "" {
+		return nil, nil
+	}
+	fee := sdk.NewCoin(r.feeCoin, sdk.OneInt())
+	for i := 0; i < maxFeeIterations; i++ {
+		tx := auth.NewStdTx([]sdk.Msg{msg}, auth.NewStdFee(0, sdk.NewCoins(fee)), nil, r.cfg.Memo)
+		breakdown, err := r.api.FeeBreakdownInCoin(tx, r.feeCoin)
+		if err != nil {
+			return nil, err
+		}
+		if breakdown.TotalInCoin.Amount.LTE(fee.Amount) {
+			return sdk.NewCoins(fee), nil
+		}
+		fee = *breakdown.TotalInCoin
+	}
+	return nil, fmt.Errorf("unable to compute fee in coin %q", r.feeCoin)
+}
+
+// multiSend creates message paying the entries.
+func (r *run) multiSend(entries []*EntryResult) decapi.MsgMultiSendCoin {
+	sends := make([]decapi.Send, len(entries))
+	for i, result := range entries {
+		receiver, _ := sdk.AccAddressFromBech32(result.Entry.Address)
+		sends[i] = decapi.Send{Receiver: receiver, Coin: sdk.NewCoin(result.Entry.Coin, result.Entry.Amount)}
+	}
+	return decapi.NewMsgMultiSendCoin(r.sender, sends)
+}
+
+// nextBatch returns entries fitting into single transaction under byte and fee ceilings.
+// Size and fee grow with amount of entries, so the batch is doubled while it fits and then bisected.
+func (r *run) nextBatch(pending []*EntryResult) ([]*EntryResult, error) {
+	fits := func(n int) (bool, error) {
+		tx := auth.NewStdTx([]sdk.Msg{r.multiSend(pending[:n])}, auth.NewStdFee(0, r.sizingFee), nil, r.cfg.Memo)
+		breakdown, err := r.api.FeeBreakdown(tx)
+		if err != nil {
+			return false, err
+		}
+		return breakdown.Bytes <= r.cfg.MaxBytes && (r.cfg.MaxFeeUnits <= 0 || breakdown.TotalUnits <= r.cfg.MaxFeeUnits), nil
+	}
+	ok, err := fits(1)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("transaction paying entry %q exceeds byte or fee ceiling", pending[0].Key)
+	}
+	// lo entries fit, hi entries do not fit
+	lo, hi := 1, 0
+	for hi == 0 {
+		n := 2 * lo
+		if n > len(pending) {
+			n = len(pending)
+		}
+		if n == lo {
+			return pending, nil
+		}
+		if ok, err = fits(n); err != nil {
+			return nil, err
+		}
+		if ok {
+			lo = n
+		} else {
+			hi = n
+		}
+	}
+	for hi-lo > 1 {
+		mid := (lo + hi) / 2
+		if ok, err = fits(mid); err != nil {
+			return nil, err
+		}
+		if ok {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return pending[:lo], nil
+}
+
+// sign signs transaction paying the entries and records it in the journal.
+func (r *run) sign(entries []*EntryResult, sequence uint64) (*job, error) {
+	keys := make([]string, len(entries))
+	for i, result := range entries {
+		keys[i] = result.Key
+	}
+	msg := r.multiSend(entries)
+	feeCoins, err := r.fee(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	r.account.WithSequence(sequence)
+	tx, err := r.api.NewSignedTransaction([]sdk.Msg{msg}, feeCoins, r.cfg.Memo, r.account)
+	if err != nil {
+		return nil, err
+	}
+	txBytes, err := r.api.Codec().MarshalBinaryLengthPrefixed(tx)
+	if err != nil {
+		return nil, err
+	}
+	jb := &job{sequence: sequence, tx: tx, hash: fmt.Sprintf("%X", tmhash.Sum(txBytes)), entries: entries}
+	b, err := r.journal.write(journalRecord{Batch: jb.hash, Status: BatchSigned, Sequence: sequence, Entries: keys, Tx: txBytes})
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range entries {
+		r.setBatchStatus(result, b)
+	}
+	return jb, nil
+}
+
+// confirm waits until the transaction is found in the blockchain and records its result.
+// Transaction which is not found in time is left in the journal as signed.
+func (r *run) confirm(ctx context.Context, jb *job) error {
+	timeout := time.NewTimer(r.cfg.ConfirmTimeout)
+	defer timeout.Stop()
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timeout.C:
+			return nil
+		case <-ticker.C:
+		}
+		tx, err := r.api.Transaction(jb.hash)
+		if err != nil || tx == nil || tx.TxResult == nil {
+			continue
+		}
+		if tx.TxResult.Code != 0 {
+			return r.finish(jb, BatchFailed, tx.TxResult.Log)
+		}
+		return r.finish(jb, BatchConfirmed, "")
+	}
+}
+
+// pendingInMempool reports whether the node rejects the transaction only because it is already in the mempool
+// or the mempool is full, so the transaction may be executed later.
+func pendingInMempool(txErr decapi.TxError) bool {
+	code := uint32(txErr.Code)
+	return code == sdkerrors.ErrTxInMempoolCache.ABCICode() || code == sdkerrors.ErrMempoolIsFull.ABCICode()
+}
+
+// record writes final status of the transaction to the journal and updates statuses of its entries.
+func (r *run) record(jb *job, status BatchStatus, reason string) error {
+	b, err := r.journal.write(journalRecord{Batch: jb.hash, Status: status, Error: reason})
+	if err != nil {
+		return err
+	}
+	for _, result := range jb.entries {
+		r.setBatchStatus(result, b)
+	}
+	return nil
+}
+
+// finish records final status of the transaction like record and returns error if it is rejected.
+func (r *run) finish(jb *job, status BatchStatus, reason string) error {
+	if err := r.record(jb, status, reason); err != nil {
+		return err
+	}
+	if status == BatchRejected {
+		return fmt.Errorf("transaction %s is rejected: %s", jb.hash, reason)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package payout
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+)
+
+// BatchStatus is a status of the batch transaction recorded in the journal.
+type BatchStatus string
+
+// Statuses of batch transactions.
+const (
+	BatchSigned    BatchStatus = "signed"    // Transaction is signed and may be broadcasted
+	BatchConfirmed BatchStatus = "confirmed" // Transaction is committed successfully
+	BatchFailed    BatchStatus = "failed"    // Transaction is committed with error, entries are not paid
+	BatchRejected  BatchStatus = "rejected"  // Transaction is rejected by the node, entries are not paid
+)
+
+// journalRecord is a single line of the journal file.
+type journalRecord struct {
+	Batch    string      `json:"batch"` // Hash of the batch transaction
+	Status   BatchStatus `json:"status"`
+	Sequence uint64      `json:"sequence,omitempty"`
+	Entries  []string    `json:"entries,omitempty"` // Keys of entries paid by the batch
+	Tx       []byte      `json:"tx,omitempty"`      // Signed transaction (amino binary)
+	Error    string      `json:"error,omitempty"`
+}
+
+// batch contains state of the batch transaction restored from the journal.
+type batch struct {
+	hash     string
+	status   BatchStatus
+	sequence uint64
+	entries  []string
+	tx       []byte
+	err      string
+}
+
+// journal is an append-only file with records about batch transactions.
+// Each record is written and synced before the corresponding action, so after a crash
+// the journal contains every transaction which could be broadcasted.
+type journal struct {
+	file *os.File
+	mtx  sync.Mutex
+
+	batches []*batch          // Batches in order of signing
+	byHash  map[string]*batch // Batches by transaction hash
+}
+
+// openJournal opens journal file (creating it if needed) and restores batches from it.
+// Partially written last line (after a crash) is removed from the file.
+func openJournal(path string) (*journal, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadAll(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if end := bytes.LastIndexByte(data, '\n') + 1; end < len(data) {
+		if err = file.Truncate(int64(end)); err != nil {
+			file.Close()
+			return nil, err
+		}
+		data = data[:end]
+	}
+	j := &journal{file: file, byHash: map[string]*batch{}}
+	for i, line := range bytes.Split(data, []byte{'\n'}) {
+		if len(line) == 0 {
+			continue
+		}
+		rec := journalRecord{}
+		if err = json.Unmarshal(line, &rec); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("journal %s, line %d: %w", path, i+1, err)
+		}
+		j.apply(rec)
+	}
+	return j, nil
+}
+
+// apply updates batches with the record and returns updated batch.
+func (j *journal) apply(rec journalRecord) *batch {
+	b, ok := j.byHash[rec.Batch]
+	if !ok {
+		b = &batch{hash: rec.Batch}
+		j.byHash[rec.Batch] = b
+		j.batches = append(j.batches, b)
+	}
+	b.status = rec.Status
+	b.err = rec.Error
+	if rec.Tx != nil {
+		b.sequence, b.entries, b.tx = rec.Sequence, rec.Entries, rec.Tx
+	}
+	return b
+}
+
+// write appends the record to the journal file, waits until it is stored on disk
+// and returns updated batch.
+func (j *journal) write(rec journalRecord) (*batch, error) {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return nil, err
+	}
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	if _, err = j.file.Write(append(data, '\n')); err != nil {
+		return nil, err
+	}
+	if err = j.file.Sync(); err != nil {
+		return nil, err
+	}
+	return j.apply(rec), nil
+}
+
+func (j *journal) close() error {
+	return j.file.Close()
+}
@@ -0,0 +1,220 @@
+// Package ranking scores validators by expected yield and reliability of delegation
+// and splits delegation amount across the best validators.
+package ranking
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	decapi "bitbucket.org/decimalteam/decimal-go-sdk/api"
+)
+
+// Default ranking options.
+const (
+	DefaultSkippedBlocksScale = 100
+)
+
+// Names of score factors.
+const (
+	FactorYield            = "yield"            // Part of rewards left to delegators after validator commission
+	FactorReliability      = "reliability"      // Penalty for skipped blocks (lost rewards, risk of slashing)
+	FactorRating           = "rating"           // Rating of the validator reported by gateway
+	FactorDecentralization = "decentralization" // Preference of validators with smaller stake
+)
+
+// Weights contains weights of score factors. Only ratio of weights matters.
+type Weights struct {
+	Yield            float64
+	Reliability      float64
+	Rating           float64
+	Decentralization float64
+}
+
+// DefaultWeights are used when all weights are zero.
+var DefaultWeights = Weights{Yield: 0.4, Reliability: 0.35, Rating: 0.15, Decentralization: 0.1}
+
+// Options contains ranking options, zero values are replaced by defaults.
+type Options struct {
+	Weights            Weights
+	SkippedBlocksScale uint64 // Amount of skipped blocks reducing reliability factor by half
+	IncludeCandidates  bool   // Rank candidates (they do not produce blocks and do not pay rewards)
+	MaxDelegations     uint64 // Delegator slots of the validator, see API.MaxDelegations (decapi.DefaultMaxDelegations if zero)
+	DirectConn         bool   // Validators are requested by direct connection (see API.IsDirectConn)
+}
+
+// Factor contains single component of the validator score.
+type Factor struct {
+	Name   string  `json:"name"`
+	Value  float64 `json:"value"`  // Value of the factor from 0 (worst) to 1 (best)
+	Weight float64 `json:"weight"` // Weight of the factor in the score
+	Detail string  `json:"detail"` // Explanation of the value
+}
+
+// Score contains score of the validator with explanation.
+type Score struct {
+	Validator *decapi.ValidatorResult `json:"validator"`
+	Score     float64                 `json:"score"`    // Weighted score from 0 (worst) to 1 (best)
+	Factors   []Factor                `json:"factors"`  // Components of the score
+	Eligible  bool                    `json:"eligible"` // Validator can be recommended for delegation
+	Reason    string                  `json:"reason"`   // Why the validator is not eligible
+	SlotsFull bool                    `json:"slotsFull"`
+	MinStake  sdk.Int                 `json:"minStake"` // Stake in base coin must exceed it when all delegator slots are used
+}
+
+// Explain returns human readable explanation of the score.
+func (s *Score) Explain() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "%s (%s): score %.3f", s.Validator.Moniker, s.Validator.Address, s.Score)
+	if !s.Eligible {
+		fmt.Fprintf(b, ", not eligible: %s", s.Reason)
+	}
+	for _, f := range s.Factors {
+		fmt.Fprintf(b, "\n  %s %.3f x %.2f: %s", f.Name, f.Value, f.Weight, f.Detail)
+	}
+	if s.SlotsFull {
+		fmt.Fprintf(b, "\n  all delegator slots are used, stake must exceed %s pip", s.MinStake)
+	}
+	return b.String()
+}
+
+// Rank scores validators and returns scores sorted from the best one.
+// Validators which are not eligible for delegation (offline, candidates) are placed at the end.
+// Commission is parsed as percent reported by gateway or as fraction reported by the node if opts.DirectConn is set.
+func Rank(validators []*decapi.ValidatorResult, opts Options) ([]*Score, error) {
+	if opts.Weights == (Weights{}) {
+		opts.Weights = DefaultWeights
+	}
+	w := opts.Weights
+	if w.Yield < 0 || w.Reliability < 0 || w.Rating < 0 || w.Decentralization < 0 {
+		return nil, errors.New("weights must not be negative")
+	}
+	if opts.SkippedBlocksScale == 0 {
+		opts.SkippedBlocksScale = DefaultSkippedBlocksScale
+	}
+	if opts.MaxDelegations == 0 {
+		opts.MaxDelegations = decapi.DefaultMaxDelegations
+	}
+
+	// Maximum stake and rating are used to normalize factors
+	stakes := make([]sdk.Int, len(validators))
+	ratings := make([]float64, len(validators))
+	maxStake, maxRating := sdk.ZeroInt(), 0.0
+	for i, v := range validators {
+		stake, err := v.StakeAmount()
+		if err != nil {
+			return nil, fmt.Errorf("validator %s: %w", v.Address, err)
+		}
+		stakes[i] = stake.Pip()
+		if stakes[i].GT(maxStake) {
+			maxStake = stakes[i]
+		}
+		if v.Rating != "" {
+			if ratings[i], err = strconv.ParseFloat(v.Rating, 64); err != nil {
+				return nil, fmt.Errorf("validator %s: invalid rating %q", v.Address, v.Rating)
+			}
+		}
+		if ratings[i] > maxRating {
+			maxRating = ratings[i]
+		}
+	}
+
+	scores := make([]*Score, len(validators))
+	for i, v := range validators {
+		commission, err := parseCommission(v.Comission, !opts.DirectConn)
+		if err != nil {
+			return nil, fmt.Errorf("validator %s: %w", v.Address, err)
+		}
+		minStake, err := v.MinStakeAmount()
+		if err != nil {
+			return nil, fmt.Errorf("validator %s: %w", v.Address, err)
+		}
+		s := &Score{Validator: v, Eligible: true, SlotsFull: v.Slots >= opts.MaxDelegations, MinStake: minStake.Pip()}
+
+		s.Factors = append(s.Factors, Factor{
+			Name:   FactorYield,
+			Value:  1 - commission,
+			Weight: w.Yield,
+			Detail: fmt.Sprintf("commission %.2f%%", commission*100),
+		})
+		scale := float64(opts.SkippedBlocksScale)
+		s.Factors = append(s.Factors, Factor{
+			Name:   FactorReliability,
+			Value:  scale / (scale + float64(v.SkippedBlocks)),
+			Weight: w.Reliability,
+			Detail: fmt.Sprintf("%d skipped blocks", v.SkippedBlocks),
+		})
+		rating := Factor{Name: FactorRating, Value: 1, Weight: w.Rating, Detail: "rating is not available"}
+		switch {
+		case maxRating > 0 && v.Rating == "":
+			rating.Value, rating.Detail = 0, "rating is not specified"
+		case maxRating > 0:
+			rating.Value = ratings[i] / maxRating
+			rating.Detail = fmt.Sprintf("rating %s of maximum %s", v.Rating, strconv.FormatFloat(maxRating, 'f', -1, 64))
+		}
+		s.Factors = append(s.Factors, rating)
+		decentralization := Factor{Name: FactorDecentralization, Value: 1, Weight: w.Decentralization, Detail: "no stake"}
+		if maxStake.IsPositive() {
+			share := stakeRatio(stakes[i], maxStake)
+			decentralization.Value = 1 - share
+			decentralization.Detail = fmt.Sprintf("stake is %.1f%% of the largest one", share*100)
+		}
+		s.Factors = append(s.Factors, decentralization)
+
+		var total, weights float64
+		for _, f := range s.Factors {
+			total += f.Value * f.Weight
+			weights += f.Weight
+		}
+		if weights > 0 {
+			s.Score = total / weights
+		}
+
+		switch {
+		case v.Status != "" && v.Status != "online":
+			s.Eligible, s.Reason = false, fmt.Sprintf("validator is %s", v.Status)
+		case v.Kind == "Candidate" && !opts.IncludeCandidates:
+			s.Eligible, s.Reason = false, "candidate does not produce blocks"
+		}
+		scores[i] = s
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Eligible != scores[j].Eligible {
+			return scores[i].Eligible
+		}
+		return scores[i].Score > scores[j].Score
+	})
+	return scores, nil
+}
+
+// parseCommission parses commission presented as percent ("10") or as fraction ("0.1") and returns fraction.
+func parseCommission(value string, percent bool) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	commission, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || commission < 0 {
+		return 0, fmt.Errorf("invalid commission %q", value)
+	}
+	if percent {
+		commission /= 100
+	}
+	if commission > 1 {
+		return 0, fmt.Errorf("invalid commission %q", value)
+	}
+	return commission, nil
+}
+
+// stakeRatio returns stake / max as float.
+func stakeRatio(stake, max sdk.Int) float64 {
+	ratio, err := strconv.ParseFloat(sdk.NewDecFromInt(stake).Quo(sdk.NewDecFromInt(max)).String(), 64)
+	if err != nil {
+		return 0
+	}
+	return ratio
+}
@@ -0,0 +1,170 @@
+package ranking
+
+import (
+	"errors"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	decapi "bitbucket.org/decimalteam/decimal-go-sdk/api"
+)
+
+// Allocation is a part of delegation amount assigned to the validator.
+type Allocation struct {
+	Validator string  `json:"validator"` // Address with prefix "dxvaloper"
+	Moniker   string  `json:"moniker"`
+	Score     float64 `json:"score"`
+	Amount    sdk.Int `json:"amount"` // Amount in pip
+}
+
+// Split splits amount of base coin (pip) across top N eligible validators proportionally to their scores,
+// so that no validator receives more than maxShare of the amount (e.g. 0.25 for 25%).
+// Validators with all delegator slots used are skipped if their part does not exceed their minimum stake.
+func Split(scores []*Score, amount sdk.Int, topN int, maxShare sdk.Dec) ([]Allocation, error) {
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("amount must be positive, got %s", amount)
+	}
+	if topN <= 0 {
+		return nil, fmt.Errorf("amount of validators must be positive, got %d", topN)
+	}
+	if !maxShare.IsPositive() || maxShare.GT(sdk.OneDec()) {
+		return nil, fmt.Errorf("maximum share must be in range (0, 1], got %s", maxShare)
+	}
+
+	candidates := make([]*Score, 0, len(scores))
+	for _, s := range scores {
+		if s.Eligible {
+			candidates = append(candidates, s)
+		}
+	}
+	for {
+		selected := candidates
+		if len(selected) > topN {
+			selected = selected[:topN]
+		}
+		if len(selected) == 0 {
+			return nil, errors.New("no eligible validators")
+		}
+		if maxShare.MulInt64(int64(len(selected))).LT(sdk.OneDec()) {
+			return nil, fmt.Errorf("%d eligible validators can not receive whole amount with maximum share %s", len(selected), maxShare)
+		}
+		amounts := allocate(selected, amount, maxShare)
+
+		// Drop the worst validator which does not accept its part and try again
+		rejected := -1
+		for i, s := range selected {
+			if s.SlotsFull && amounts[i].LTE(s.MinStake) {
+				rejected = i
+			}
+		}
+		if rejected < 0 {
+			allocations := make([]Allocation, 0, len(selected))
+			for i, s := range selected {
+				if amounts[i].IsZero() {
+					continue
+				}
+				allocations = append(allocations, Allocation{
+					Validator: s.Validator.Address,
+					Moniker:   s.Validator.Moniker,
+					Score:     s.Score,
+					Amount:    amounts[i],
+				})
+			}
+			return allocations, nil
+		}
+		candidates = append(candidates[:rejected:rejected], candidates[rejected+1:]...)
+	}
+}
+
+// allocate distributes amount proportionally to scores with every part limited by maxShare of the amount.
+// Parts exceeding the limit are capped and the rest is distributed among other validators again.
+func allocate(selected []*Score, amount sdk.Int, maxShare sdk.Dec) []sdk.Int {
+	limit := maxShare.MulInt(amount)
+	parts := make([]sdk.Dec, len(selected))
+	capped := make([]bool, len(selected))
+	remaining := sdk.NewDecFromInt(amount)
+	for {
+		total := sdk.ZeroDec()
+		for i, s := range selected {
+			if !capped[i] {
+				total = total.Add(scoreWeight(s))
+			}
+		}
+		exceeded := false
+		for i, s := range selected {
+			if capped[i] {
+				continue
+			}
+			parts[i] = remaining.Mul(scoreWeight(s)).Quo(total)
+			if parts[i].GT(limit) {
+				parts[i], capped[i], exceeded = limit, true, true
+				remaining = remaining.Sub(limit)
+			}
+		}
+		if !exceeded {
+			break
+		}
+	}
+
+	// Parts are truncated to whole pip, rest is given to the best validators below the limit
+	amounts := make([]sdk.Int, len(selected))
+	rest := amount
+	for i := range parts {
+		amounts[i] = parts[i].TruncateInt()
+		rest = rest.Sub(amounts[i])
+	}
+	limitInt := limit.TruncateInt()
+	for rest.IsPositive() {
+		given := false
+		for i := range amounts {
+			if rest.IsPositive() && amounts[i].LT(limitInt) {
+				amounts[i] = amounts[i].AddRaw(1)
+				rest = rest.SubRaw(1)
+				given = true
+			}
+		}
+		if !given {
+			// Limit is not whole amount of pip, so the last pip exceeds it
+			amounts[0] = amounts[0].Add(rest)
+			break
+		}
+	}
+	return amounts
+}
+
+// scoreWeight returns score of the validator used as weight of its part (equal weights for zero scores).
+func scoreWeight(s *Score) sdk.Dec {
+	weight, err := sdk.NewDecFromStr(fmt.Sprintf("%.6f", s.Score))
+	if err != nil || !weight.IsPositive() {
+		return sdk.NewDecWithPrec(1, 6)
+	}
+	return weight
+}
+
+// Recommend requests active validators, ranks them and splits amount of base coin (pip)
+// across top N of them under maximum share per validator (see Rank and Split).
+// opts.DirectConn is set according to the connection of the API instance.
+// Gateway: ok, REST/RPC: partial (skipped blocks, slots and rating are not available)
+func Recommend(api *decapi.API, amount sdk.Int, topN int, maxShare sdk.Dec, opts Options) ([]Allocation, []*Score, error) {
+	opts.DirectConn = api.IsDirectConn()
+	validators, err := api.Validators()
+	if err != nil {
+		return nil, nil, err
+	}
+	if opts.IncludeCandidates {
+		candidates, err := api.Candidates()
+		if err != nil {
+			return nil, nil, err
+		}
+		validators = append(validators, candidates...)
+	}
+	scores, err := Rank(validators, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	allocations, err := Split(scores, amount, topN, maxShare)
+	if err != nil {
+		return nil, scores, err
+	}
+	return allocations, scores, nil
+}
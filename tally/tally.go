@@ -0,0 +1,173 @@
+// Package tally calculates the result of governance proposal voting the same way the node does
+// and forecasts the outcome depending on votes of validators which have not voted yet.
+//
+// Only votes of MaxVoters validators with the largest stakes are counted, validators which have not voted
+// are counted as abstained. The proposal passes if Yes stake exceeds quorum of total stake of counted validators.
+// Decimal governance has no veto option (votes are Yes, No and Abstain), so proposals are never vetoed.
+package tally
+
+import (
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	decapi "bitbucket.org/decimalteam/decimal-go-sdk/api"
+)
+
+// MaxVoters is an amount of validators with the largest stakes whose votes are counted.
+const MaxVoters = 9
+
+// Outcome is a forecast of proposal voting.
+type Outcome string
+
+// Outcomes of proposal voting.
+const (
+	OutcomePassed    Outcome = "passed"    // Proposal passes whatever remaining validators vote
+	OutcomeRejected  Outcome = "rejected"  // Proposal is rejected whatever remaining validators vote
+	OutcomeUndecided Outcome = "undecided" // Outcome depends on votes of remaining validators
+)
+
+// Voter is a validator whose vote is counted.
+type Voter struct {
+	Validator string  `json:"validator"` // Address with prefix "dxvaloper"
+	Moniker   string  `json:"moniker"`
+	Stake     sdk.Int `json:"stake"` // Stake in pip
+	Vote      string  `json:"vote"`  // Vote option ("Yes", "No", "Abstain") or empty if the validator has not voted
+}
+
+// Result contains tally of the proposal and forecast of its outcome.
+type Result struct {
+	Params   decapi.TallyParams    `json:"params"`
+	Voters   []Voter               `json:"voters"`   // Counted validators sorted by stake
+	NotVoted []Voter               `json:"notVoted"` // Counted validators which have not voted yet
+	Ignored  []decapi.ProposalVote `json:"ignored"`  // Votes of validators which are not counted
+
+	Tally         decapi.ProposalTally `json:"tally"`         // Stakes of voted validators
+	NotVotedStake sdk.Int              `json:"notVotedStake"` // Stake of validators which have not voted yet
+	TotalStake    sdk.Int              `json:"totalStake"`    // Stake of counted validators
+
+	Participation    sdk.Dec `json:"participation"`    // Part of total stake which has voted
+	YesShare         sdk.Dec `json:"yesShare"`         // Part of total stake which has voted Yes
+	QuorumReached    bool    `json:"quorumReached"`    // Yes stake exceeds quorum of total stake
+	ThresholdReached bool    `json:"thresholdReached"` // Yes stake exceeds threshold of Yes and No stakes
+	Passes           bool    `json:"passes"`           // Proposal passes if voting ends now
+
+	Outcome   Outcome `json:"outcome"`
+	YesNeeded sdk.Int `json:"yesNeeded"` // Additional Yes stake required to pass the proposal (zero if it passes)
+}
+
+// Calculate calculates tally of votes for the proposal using stakes of validators.
+// Only bonded validators take part in tallying, so candidates and offline validators are ignored
+// (gateway lists offline validators among validators). Forecast assumes that validators which have voted do not change their votes
+// and stakes of validators do not change until the end of voting.
+func Calculate(votes []decapi.ProposalVote, validators []*decapi.ValidatorResult, params decapi.TallyParams) (*Result, error) {
+	if params.Quorum.IsNil() || params.Threshold.IsNil() {
+		return nil, fmt.Errorf("tallying parameters are not specified")
+	}
+
+	voters := make([]Voter, 0, len(validators))
+	for _, v := range validators {
+		if v.Kind == "Candidate" || (v.Status != "" && v.Status != "online") {
+			continue
+		}
+		stake, err := v.StakeAmount()
+		if err != nil {
+			return nil, fmt.Errorf("validator %s: %w", v.Address, err)
+		}
+		voters = append(voters, Voter{Validator: v.Address, Moniker: v.Moniker, Stake: stake.Pip()})
+	}
+	sort.SliceStable(voters, func(i, j int) bool {
+		return voters[i].Stake.GT(voters[j].Stake)
+	})
+	if len(voters) > MaxVoters {
+		voters = voters[:MaxVoters]
+	}
+
+	index := make(map[string]int, len(voters))
+	for i, v := range voters {
+		index[v.Validator] = i
+	}
+	r := &Result{Params: params}
+	for _, vote := range votes {
+		option, err := decapi.ParseVoteOption(vote.Vote)
+		if err != nil {
+			return nil, fmt.Errorf("vote of validator %s: %w", vote.ValidatorId, err)
+		}
+		i, ok := index[vote.ValidatorId]
+		if !ok {
+			r.Ignored = append(r.Ignored, vote)
+			continue
+		}
+		// The node keeps only the last vote of the validator
+		voters[i].Vote = option.String()
+	}
+	r.Voters = voters
+
+	r.Tally = decapi.ProposalTally{Yes: sdk.ZeroInt(), Abstain: sdk.ZeroInt(), No: sdk.ZeroInt()}
+	r.NotVotedStake, r.TotalStake = sdk.ZeroInt(), sdk.ZeroInt()
+	for _, v := range voters {
+		r.TotalStake = r.TotalStake.Add(v.Stake)
+		switch v.Vote {
+		case decapi.VoteOptionYes.String():
+			r.Tally.Yes = r.Tally.Yes.Add(v.Stake)
+		case decapi.VoteOptionAbstain.String():
+			r.Tally.Abstain = r.Tally.Abstain.Add(v.Stake)
+		case decapi.VoteOptionNo.String():
+			r.Tally.No = r.Tally.No.Add(v.Stake)
+		default:
+			r.NotVoted = append(r.NotVoted, v)
+			r.NotVotedStake = r.NotVotedStake.Add(v.Stake)
+		}
+	}
+
+	r.Participation, r.YesShare = sdk.ZeroDec(), sdk.ZeroDec()
+	if r.TotalStake.IsPositive() {
+		r.Participation = sdk.NewDecFromInt(r.Tally.Total()).QuoInt(r.TotalStake)
+		r.YesShare = sdk.NewDecFromInt(r.Tally.Yes).QuoInt(r.TotalStake)
+	}
+	r.QuorumReached = passes(r.Tally.Yes, r.TotalStake, params.Quorum)
+	r.ThresholdReached = passes(r.Tally.Yes, r.Tally.Yes.Add(r.Tally.No), params.Threshold)
+	r.Passes = r.QuorumReached
+
+	// Votes of remaining validators do not change total stake, so the best case is all of them voting Yes
+	r.YesNeeded = sdk.ZeroInt()
+	switch {
+	case r.Passes:
+		r.Outcome = OutcomePassed
+	case !passes(r.Tally.Yes.Add(r.NotVotedStake), r.TotalStake, params.Quorum):
+		r.Outcome = OutcomeRejected
+	default:
+		r.Outcome = OutcomeUndecided
+	}
+	if !r.Passes && r.TotalStake.IsPositive() {
+		r.YesNeeded = params.Quorum.MulInt(r.TotalStake).TruncateInt().AddRaw(1).Sub(r.Tally.Yes)
+	}
+	return r, nil
+}
+
+// passes checks exactly that stake / total > part (proposal with zero total stake never passes).
+func passes(stake, total sdk.Int, part sdk.Dec) bool {
+	if !total.IsPositive() || !stake.IsPositive() {
+		return false
+	}
+	return sdk.NewDecFromInt(stake).GT(part.MulInt(total))
+}
+
+// Forecast requests votes for the proposal, active validators and tallying parameters and calculates the tally.
+// Gateway: ok (default tallying parameters), REST/RPC: ok
+func Forecast(api *decapi.API, proposalID int64) (*Result, error) {
+	votes, err := api.ProposalVotes(proposalID)
+	if err != nil {
+		return nil, err
+	}
+	validators, err := api.Validators()
+	if err != nil {
+		return nil, err
+	}
+	params, err := api.GovTallyParams()
+	if err != nil {
+		return nil, err
+	}
+	return Calculate(votes, validators, params)
+}
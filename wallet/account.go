@@ -3,6 +3,7 @@ package wallet
 import (
 	"crypto/sha256"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/btcsuite/btcd/chaincfg"
@@ -185,11 +186,24 @@ func (acc *Account) SignTransaction(tx auth.StdTx) (auth.StdTx, error) {
 func (acc *Account) IssueCheck(coinSymbol string, amount sdk.Int, nonce sdk.Int, dueBlock uint64, passphrase string) (string, error) {
 
 	// TODO: Check if coin exists?
-	// TODO: Check amount?
+
+	// Check chain ID, amount and nonce
+	if len(acc.chainID) == 0 {
+		return "", errors.New("chain ID is not set up")
+	}
+	if !amount.IsPositive() {
+		return "", errors.New("check amount must be positive")
+	}
+	if len(nonce.BigInt().Bytes()) > MaxCheckNonceLength {
+		return "", fmt.Errorf("check nonce must not be longer than %d bytes", MaxCheckNonceLength)
+	}
 
 	// Prepare private key from passphrase
 	passphraseHash := sha256.Sum256([]byte(passphrase))
-	passphrasePrivKey, _ := crypto.ToECDSA(passphraseHash[:])
+	passphrasePrivKey, err := crypto.ToECDSA(passphraseHash[:])
+	if err != nil {
+		return "", err
+	}
 
 	// Prepare check without lock
 	check := &Check{
@@ -202,7 +216,10 @@ func (acc *Account) IssueCheck(coinSymbol string, amount sdk.Int, nonce sdk.Int,
 
 	// Prepare check lock
 	checkHash := check.HashWithoutLock()
-	lock, _ := crypto.Sign(checkHash[:], passphrasePrivKey)
+	lock, err := crypto.Sign(checkHash[:], passphrasePrivKey)
+	if err != nil {
+		return "", err
+	}
 
 	// Fill check with prepared lock
 	check.Lock = big.NewInt(0).SetBytes(lock)
@@ -210,22 +227,21 @@ func (acc *Account) IssueCheck(coinSymbol string, amount sdk.Int, nonce sdk.Int,
 	// Retrieve private key from the keybase account
 	privKeyECDSA, err := crypto.ToECDSA(acc.privateKeyTM[:])
 	if err != nil {
-		panic(err)
+		return "", err
 	}
-	// address := sdk.AccAddress(privKey.PubKey().Address())
 
 	// Sign check by check issuer
 	checkHash = check.Hash()
 	signature, err := crypto.Sign(checkHash[:], privKeyECDSA)
 	if err != nil {
-		panic(err)
+		return "", err
 	}
 	check.SetSignature(signature)
 
 	// Return issued raw check encoded to base64 format to the issuer
 	checkBytes, err := rlp.EncodeToBytes(check)
 	if err != nil {
-		panic(err)
+		return "", err
 	}
 
 	return base58.Encode(checkBytes), nil
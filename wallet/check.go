@@ -3,7 +3,9 @@ package wallet
 import (
 	"bytes"
 	"crypto/ecdsa"
+	"crypto/rand"
 	"crypto/sha256"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"math/big"
@@ -19,6 +21,9 @@ import (
 // HashLength represents fixed hash length.
 const HashLength = 32
 
+// MaxCheckNonceLength is maximum length of check nonce in bytes accepted by the node.
+const MaxCheckNonceLength = 16
+
 // Hash represents the 32 byte Keccak256 hash of arbitrary data.
 type Hash [HashLength]byte
 
@@ -133,6 +138,15 @@ func (check *Check) String() string {
 	// return fmt.Sprintf("Check nonce: %x, dueBlock: %d, value: %s %s", check.Nonce, check.DueBlock, check.Amount.String(), check.Coin)
 }
 
+// NewCheckNonce generates random (crypto safe) check nonce with maximum allowed length.
+func NewCheckNonce() (sdk.Int, error) {
+	nonce := make([]byte, MaxCheckNonceLength)
+	if _, err := rand.Read(nonce); err != nil {
+		return sdk.Int{}, err
+	}
+	return sdk.NewIntFromBigInt(new(big.Int).SetBytes(nonce)), nil
+}
+
 // ParseCheck parses check from bytes.
 func ParseCheck(buf []byte) (*Check, error) {
 	var check Check
@@ -148,6 +162,22 @@ func ParseCheck(buf []byte) (*Check, error) {
 	return &check, nil
 }
 
+// NewCheckRedeemProof creates proof allowing account with specified address to redeem check
+// issued with specified passphrase. Proof is returned in base64 format as expected by MsgRedeemCheck.
+func NewCheckRedeemProof(address sdk.AccAddress, passphrase string) (string, error) {
+	passphraseHash := sha256.Sum256([]byte(passphrase))
+	passphrasePrivKey, err := crypto.ToECDSA(passphraseHash[:])
+	if err != nil {
+		return "", err
+	}
+	addressHash := rlpHash([]interface{}{address})
+	proof, err := crypto.Sign(addressHash[:], passphrasePrivKey)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(proof), nil
+}
+
 func rlpHash(x interface{}) (h Hash) {
 	hw := sha3.NewLegacyKeccak256()
 	err := rlp.Encode(hw, x)